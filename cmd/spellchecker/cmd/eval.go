@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"github.com/spf13/cobra"
 	"github.com/suggest-go/suggest/internal/spellchecker/dep"
@@ -60,7 +61,7 @@ var evalCmd = &cobra.Command{
 			}
 
 			start := time.Now()
-			result, err := service.Predict(sentence, topK, similarity)
+			result, err := service.Predict(context.Background(), sentence, topK, similarity)
 			elapsed := time.Since(start).String()
 
 			if err != nil {
@@ -8,11 +8,13 @@ import (
 )
 
 var (
-	port string
+	port     string
+	grpcPort string
 )
 
 func init() {
 	spellcheckerCmd.Flags().StringVarP(&port, "port", "p", "8080", "listen port")
+	spellcheckerCmd.Flags().StringVar(&grpcPort, "grpc-port", "", "listen port of the gRPC server, the gRPC server is not started if it is empty")
 
 	rootCmd.AddCommand(spellcheckerCmd)
 }
@@ -20,7 +22,7 @@ func init() {
 var spellcheckerCmd = &cobra.Command{
 	Use:   "service-run -c [config path] -p [port]",
 	Short: "runs http server",
-	Long:  "runs http server with REST API",
+	Long:  "runs http server with REST API and optionally gRPC server",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.SetPrefix("spellchecker: ")
 		log.SetFlags(0)
@@ -29,6 +31,7 @@ var spellcheckerCmd = &cobra.Command{
 			Port:       port,
 			ConfigPath: configPath,
 			IndexDescription: indexDescription,
+			GRPCPort:         grpcPort,
 		}
 
 		app := api.NewApp(config)
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/suggest-go/suggest/pkg/store"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+func init() {
+	compactCmd.Flags().StringVarP(&dict, "dict", "d", "", "dictionary name")
+	compactCmd.MarkFlagRequired("dict")
+	compactCmd.Flags().StringVarP(&host, "host", "", "", "host to send reindex request")
+
+	rootCmd.AddCommand(compactCmd)
+}
+
+var compactCmd = &cobra.Command{
+	Use:   "compact -c [config file] -d [dict]",
+	Short: "merges index segments into a single one",
+	Long: `merges index segments into a single one, drops deleted documents and send signal to reload suggest-service.
+The rest documents are renumbered densely, so the document keys known before the compaction
+refer to other documents after it, the documents should be looked up again before the next update`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		log.SetPrefix("compact: ")
+		log.SetFlags(0)
+
+		configs, err := readConfigs()
+
+		if err != nil {
+			return err
+		}
+
+		compacted := false
+
+		for _, config := range configs {
+			if dict != config.Name {
+				continue
+			}
+
+			if config.Driver != suggest.DiscDriver {
+				return fmt.Errorf("dictionary %s is not an on-disc one", dict)
+			}
+
+			log.Printf("Start compacting '%s'", config.Name)
+			start := time.Now()
+
+			directory, err := store.NewFSDirectory(config.GetIndexPath())
+
+			if err != nil {
+				return fmt.Errorf("failed to create a directory: %w", err)
+			}
+
+			if err := suggest.Compact(directory, config); err != nil {
+				return err
+			}
+
+			log.Printf("Time spent %s", time.Since(start))
+			compacted = true
+		}
+
+		if !compacted {
+			return fmt.Errorf("dictionary %s is not found", dict)
+		}
+
+		if pidPath != "" {
+			if err := tryToSendReindexSignal(); err != nil {
+				return err
+			}
+		}
+
+		if host != "" {
+			if err := tryToSendReindexRequest(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"os"
 	"strings"
@@ -57,7 +58,7 @@ var evalCmd = &cobra.Command{
 			}
 
 			start := time.Now()
-			result, err := suggestService.Suggest(dict, searchConf)
+			result, err := suggestService.Suggest(context.Background(), dict, searchConf)
 			elapsed := time.Since(start).String()
 
 			if err != nil {
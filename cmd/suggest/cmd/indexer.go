@@ -1,22 +1,20 @@
 package cmd
 
 import (
-	"bufio"
 	"bytes"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"os"
 	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/suggest-go/suggest/pkg/index"
 	"github.com/suggest-go/suggest/pkg/store"
 
 	"github.com/spf13/cobra"
 
-	"github.com/suggest-go/suggest/pkg/dictionary"
 	"github.com/suggest-go/suggest/pkg/suggest"
 )
 
@@ -104,11 +102,26 @@ func indexJob(description suggest.IndexDescription) error {
 		return nil
 	}
 
+	directory, err := store.NewFSDirectory(description.GetIndexPath())
+
+	if err != nil {
+		return fmt.Errorf("failed to create a directory: %w", err)
+	}
+
+	// the base segment is rebuilt and the delta segments are dropped, so the updates have to wait
+	lock, err := suggest.LockIndex(directory, description)
+
+	if err != nil {
+		return err
+	}
+
+	defer lock.Close()
+
 	// create a cdb dictionary
 	log.Printf("Building a dictionary...")
 	start := time.Now()
 
-	dict, err := buildDictionaryJob(description)
+	dict, err := suggest.BuildDictionary(directory, description)
 
 	if err != nil {
 		return fmt.Errorf("failed to build a dictionary: %w", err)
@@ -120,73 +133,31 @@ func indexJob(description suggest.IndexDescription) error {
 	log.Printf("Creating a search index...")
 	start = time.Now()
 
-	directory, err := store.NewFSDirectory(description.GetIndexPath())
+	tokenizer, err := suggest.BuildIndexTokenizer(directory, dict, description)
 
 	if err != nil {
-		return fmt.Errorf("failed to create a directory: %w", err)
+		return fmt.Errorf("failed to create a tokenizer: %w", err)
 	}
 
-	if err = suggest.Index(directory, dict, description.GetWriterConfig(), description.GetIndexTokenizer()); err != nil {
+	if err = suggest.Index(directory, dict, description.GetWriterConfig(), tokenizer); err != nil {
 		return err
 	}
 
-	log.Printf("Time spent %s", time.Since(start))
-	log.Printf("End process\n\n")
-
-	return nil
-}
-
-// buildDictionary builds a persistent dictionary
-func buildDictionaryJob(config suggest.IndexDescription) (dictionary.Dictionary, error) {
-	dictReader, err := newDictionaryReader(config)
-
-	if err != nil {
-		return nil, err
-	}
-
-	dict, err := dictionary.BuildCDBDictionary(dictReader, config.GetDictionaryFile())
+	// the delta segments were created for the previous base segment, so we have to drop them
+	dropped, err := index.ResetSegments(directory, description.Name)
 
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to reset index segments: %w", err)
 	}
 
-	return dict, nil
-}
-
-// dictionaryReader is an adapter, that implements dictionary.Iterable for bufio.Scanner
-type dictionaryReader struct {
-	lineScanner *bufio.Scanner
-}
-
-// Iterate iterates through each line of the corresponding dictionary
-func (dr *dictionaryReader) Iterate(iterator dictionary.Iterator) error {
-	docID := dictionary.Key(0)
-
-	for dr.lineScanner.Scan() {
-		if err := iterator(docID, dr.lineScanner.Text()); err != nil {
-			return err
-		}
-
-		docID++
-	}
-
-	return dr.lineScanner.Err()
-}
-
-// newDictionaryReader creates an adapter to Iterable interface, that scans all lines
-// from the SourcePath and creates pairs of <DocID, Value>
-func newDictionaryReader(config suggest.IndexDescription) (dictionary.Iterable, error) {
-	f, err := os.Open(config.GetSourcePath())
-
-	if err != nil {
-		return nil, fmt.Errorf("could not open a source file %w", err)
+	if err := suggest.RemoveSegmentFiles(directory, description, dropped); err != nil {
+		return err
 	}
 
-	scanner := bufio.NewScanner(f)
+	log.Printf("Time spent %s", time.Since(start))
+	log.Printf("End process\n\n")
 
-	return &dictionaryReader{
-		lineScanner: scanner,
-	}, nil
+	return nil
 }
 
 // tryToSendReindexSignal sends a SIGHUP signal to the pid
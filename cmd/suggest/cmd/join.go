@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+const (
+	tsvFormat  = "tsv"
+	jsonFormat = "json"
+)
+
+var (
+	leftPath       string
+	rightPath      string
+	selfJoin       bool
+	joinFormat     string
+	joinMetric     string
+	joinTopK       int
+	joinSimilarity float64
+)
+
+func init() {
+	joinCmd.Flags().StringVarP(&dict, "dict", "d", "", "dictionary name, which index description is used to tokenize the strings")
+	joinCmd.MarkFlagRequired("dict")
+
+	joinCmd.Flags().StringVarP(&leftPath, "left", "a", "", "path to the file, which lines are matched")
+	joinCmd.Flags().StringVarP(&rightPath, "right", "b", "", "path to the file, which lines are indexed")
+	joinCmd.MarkFlagRequired("right")
+	joinCmd.Flags().BoolVarP(&selfJoin, "self", "", false, "cluster the near-duplicates of the right file instead of the join")
+	joinCmd.Flags().StringVarP(&joinFormat, "format", "f", tsvFormat, "output format, tsv or json")
+	joinCmd.Flags().StringVarP(&joinMetric, "metric", "m", "Cosine", "similarity metric, Cosine, Jaccard, Dice, Exact, Overlap, IDFJaccard, IDFCosine or JaroWinkler")
+	joinCmd.Flags().IntVarP(&joinTopK, "topK", "k", 5, "maximum number of the matches of a line")
+	joinCmd.Flags().Float64VarP(&joinSimilarity, "sim", "s", 0.5, "similarity of the matches")
+
+	rootCmd.AddCommand(joinCmd)
+}
+
+var joinCmd = &cobra.Command{
+	Use:   "join -c [config path] -d [dict] -a [left file] -b [right file]",
+	Short: "fuzzy join of two string files",
+	Long: `finds the similar lines of the right file for each line of the left file and prints the pairs with their scores,
+with --self clusters the near-duplicates of the right file`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if joinFormat != tsvFormat && joinFormat != jsonFormat {
+			return fmt.Errorf("output format %s is not supported", joinFormat)
+		}
+
+		if !selfJoin && leftPath == "" {
+			return fmt.Errorf("left file should be set for a join")
+		}
+
+		description, err := findDescription()
+
+		if err != nil {
+			return err
+		}
+
+		m, err := metric.GetMetric(joinMetric)
+
+		if err != nil {
+			return err
+		}
+
+		lines, err := readLines(rightPath)
+
+		if err != nil {
+			return err
+		}
+
+		joiner, err := suggest.NewJoiner(dictionary.NewInMemoryDictionary(lines), description, suggest.JoinConfig{
+			TopK:       joinTopK,
+			Similarity: joinSimilarity,
+			Metric:     m,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		out := bufio.NewWriter(os.Stdout)
+		defer out.Flush()
+
+		if selfJoin {
+			return writeClusters(out, joiner, lines)
+		}
+
+		left, err := os.Open(leftPath)
+
+		if err != nil {
+			return fmt.Errorf("failed to open left file: %w", err)
+		}
+
+		defer left.Close()
+
+		return joiner.Join(context.Background(), left, func(pair suggest.JoinPair) error {
+			return writeRecord(out, pair, []string{pair.Left, pair.Right, fmt.Sprintf("%f", pair.Score)})
+		})
+	},
+}
+
+// findDescription returns the description of the dictionary
+func findDescription() (suggest.IndexDescription, error) {
+	configs, err := readConfigs()
+
+	if err != nil {
+		return suggest.IndexDescription{}, err
+	}
+
+	for _, config := range configs {
+		if config.Name == dict {
+			return config, nil
+		}
+	}
+
+	return suggest.IndexDescription{}, fmt.Errorf("Dictionary %s is not found", dict)
+}
+
+// readLines returns the lines of the file
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	defer file.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	return lines, nil
+}
+
+// writeClusters deduplicates the lines and writes each cluster of near-duplicates
+func writeClusters(out io.Writer, joiner *suggest.Joiner, lines []string) error {
+	clusters, err := joiner.Deduplicate(context.Background())
+
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		values := make([]string, 0, len(cluster))
+
+		for _, key := range cluster {
+			values = append(values, lines[key])
+		}
+
+		if err := writeRecord(out, values, values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeRecord writes the record as a JSON line or the given fields as a TSV line
+func writeRecord(out io.Writer, record interface{}, fields []string) error {
+	if joinFormat == jsonFormat {
+		data, err := json.Marshal(record)
+
+		if err != nil {
+			return fmt.Errorf("failed to encode record: %w", err)
+		}
+
+		_, err = fmt.Fprintf(out, "%s\n", data)
+
+		return err
+	}
+
+	_, err := fmt.Fprintln(out, strings.Join(fields, "\t"))
+
+	return err
+}
@@ -3,16 +3,23 @@ package cmd
 import (
 	"github.com/suggest-go/suggest/internal/suggest/api"
 	"log"
+	"time"
 
 	"github.com/spf13/cobra"
 )
 
 var (
-	port string
+	port         string
+	timeout      time.Duration
+	batchWorkers int
+	grpcPort     string
 )
 
 func init() {
 	suggestCmd.Flags().StringVarP(&port, "port", "p", "8080", "listen port")
+	suggestCmd.Flags().DurationVarP(&timeout, "timeout", "t", time.Second, "search timeout of a request, 0 means no timeout")
+	suggestCmd.Flags().IntVar(&batchWorkers, "batch-workers", 0, "number of goroutines serving the queries of a batch request, 0 means GOMAXPROCS")
+	suggestCmd.Flags().StringVar(&grpcPort, "grpc-port", "", "listen port of the gRPC server, the gRPC server is not started if it is empty")
 
 	rootCmd.AddCommand(suggestCmd)
 }
@@ -20,15 +27,18 @@ func init() {
 var suggestCmd = &cobra.Command{
 	Use:   "service-run -c [config path] -p [port]",
 	Short: "runs http server",
-	Long:  "runs http server with REST API",
+	Long:  "runs http server with REST API and optionally gRPC server",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		log.SetPrefix("suggest: ")
 		log.SetFlags(0)
 
 		config := api.AppConfig{
-			Port:       port,
-			ConfigPath: configPath,
-			PidPath:    pidPath,
+			Port:         port,
+			ConfigPath:   configPath,
+			PidPath:      pidPath,
+			Timeout:      timeout,
+			BatchWorkers: batchWorkers,
+			GRPCPort:     grpcPort,
 		}
 
 		app := api.NewApp(config)
@@ -7,6 +7,7 @@ require (
 	github.com/alldroll/cdb v1.0.2
 	github.com/alldroll/rbtree v0.0.0-20201026153457-c76906afcaa0
 	github.com/edsrzf/mmap-go v0.0.0-20190108065903-904c4ced31cd
+	github.com/golang/protobuf v1.4.2
 	github.com/gorilla/handlers v1.4.0
 	github.com/gorilla/mux v1.7.1
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
@@ -15,4 +16,7 @@ require (
 	github.com/spf13/pflag v1.0.3 // indirect
 	github.com/stretchr/testify v1.6.1
 	golang.org/x/sync v0.0.0-20200625203802-6e8e738ad208
+	golang.org/x/text v0.3.3
+	google.golang.org/grpc v1.35.0
+	google.golang.org/protobuf v1.25.0
 )
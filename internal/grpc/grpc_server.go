@@ -0,0 +1,51 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+// Server serves gRPC requests, the services are registered by the generated Register functions
+type Server struct {
+	srv  *grpc.Server
+	addr string
+}
+
+// NewServer creates new instance of Server
+func NewServer(addr string) *Server {
+	return &Server{
+		srv:  grpc.NewServer(),
+		addr: addr,
+	}
+}
+
+// RegisterService registers a service and its implementation, it should be called before Run
+func (s *Server) RegisterService(desc *grpc.ServiceDesc, impl interface{}) {
+	s.srv.RegisterService(desc, impl)
+}
+
+// Run starts serving gRPC requests until the context is done
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+
+	if err != nil {
+		return fmt.Errorf("failed to listen %s: %w", s.addr, err)
+	}
+
+	go func() {
+		<-ctx.Done()
+		s.srv.GracefulStop()
+	}()
+
+	if err := s.srv.Serve(listener); err != nil {
+		return err
+	}
+
+	log.Println("gRPC server was shutdown gracefully")
+
+	return nil
+}
@@ -10,10 +10,13 @@ import (
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
+	"github.com/suggest-go/suggest/internal/grpc"
 	"github.com/suggest-go/suggest/internal/http"
 	"github.com/suggest-go/suggest/internal/spellchecker/dep"
 	"github.com/suggest-go/suggest/pkg/lm"
 	"github.com/suggest-go/suggest/pkg/suggest"
+	"github.com/suggest-go/suggest/pkg/suggestpb"
+	"golang.org/x/sync/errgroup"
 )
 
 // App is our application
@@ -27,6 +30,8 @@ type AppConfig struct {
 	ConfigPath       string
 	PidPath          string
 	IndexDescription suggest.IndexDescription
+	// GRPCPort is the listen port of the gRPC server, the gRPC server is not started if it is empty
+	GRPCPort string
 }
 
 // NewApp creates new instance of App for the given config
@@ -70,7 +75,24 @@ func (a App) Run() error {
 	handler = handlers.CORS(corsHeaders, corsMethods)(handler)
 	httpServer := http.NewServer(handler, "0.0.0.0:"+a.config.Port)
 
-	return httpServer.Run(ctx)
+	if a.config.GRPCPort == "" {
+		return httpServer.Run(ctx)
+	}
+
+	grpcServer := grpc.NewServer("0.0.0.0:" + a.config.GRPCPort)
+	suggestpb.RegisterSpellcheckerServer(grpcServer, &grpcHandler{
+		spellchecker: spellchecker,
+	})
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return httpServer.Run(ctx)
+	})
+	group.Go(func() error {
+		return grpcServer.Run(ctx)
+	})
+
+	return group.Wait()
 }
 
 // listenToSystemSignals handles OS signals
@@ -0,0 +1,96 @@
+package api
+
+import (
+	"context"
+	"errors"
+
+	"github.com/suggest-go/suggest/pkg/spellchecker"
+	"github.com/suggest-go/suggest/pkg/suggestpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultTopK       = 5
+	defaultSimilarity = 0.5
+)
+
+// grpcHandler implements the Spellchecker gRPC service
+type grpcHandler struct {
+	suggestpb.UnimplementedSpellcheckerServer
+	spellchecker *spellchecker.SpellChecker
+}
+
+// Predict performs prediction for the provided query
+func (h *grpcHandler) Predict(ctx context.Context, req *suggestpb.PredictRequest) (*suggestpb.PredictResponse, error) {
+	predictions, err := h.predict(ctx, req)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &suggestpb.PredictResponse{
+		Predictions: predictions,
+	}, nil
+}
+
+// PredictBatch performs prediction for each query of the batch and sends
+// the predictions as soon as they are done in the order of the queries
+func (h *grpcHandler) PredictBatch(req *suggestpb.PredictBatchRequest, stream suggestpb.Spellchecker_PredictBatchServer) error {
+	for i, query := range req.Queries {
+		response := &suggestpb.PredictBatchResponse{
+			Index: int32(i),
+		}
+
+		predictions, err := h.predict(stream.Context(), query)
+
+		switch {
+		case status.Code(err) == codes.Canceled:
+			return err
+		case err != nil:
+			response.Error = status.Convert(err).Message()
+		default:
+			response.Predictions = predictions
+		}
+
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// predict returns the predictions of the query, the zero fields of the query take the default values
+func (h *grpcHandler) predict(ctx context.Context, req *suggestpb.PredictRequest) ([]string, error) {
+	topK, similarity := defaultTopK, defaultSimilarity
+
+	if req.TopK != 0 {
+		topK = int(req.TopK)
+	}
+
+	if req.Similarity != 0 {
+		similarity = req.Similarity
+	}
+
+	if topK < 0 {
+		return nil, status.Error(codes.InvalidArgument, "topK should be positive integer")
+	}
+
+	if similarity < 0 || similarity > 1 {
+		return nil, status.Error(codes.InvalidArgument, "similarity should be in [0, 1] range")
+	}
+
+	predictions, err := h.spellchecker.Predict(ctx, req.Query, topK, similarity)
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return nil, status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, status.Error(codes.DeadlineExceeded, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return predictions, nil
+}
@@ -22,21 +22,21 @@ func (h *predictHandler) handle(w http.ResponseWriter, r *http.Request) {
 		query = vars["query"]
 	)
 
-	topK, err := httputil.FormTopKValue(r, "topK", 5)
+	topK, err := httputil.FormTopKValue(r, "topK", defaultTopK)
 
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	similarity, err := httputil.FormSimilarityValue(r, "similarity", 0.5)
+	similarity, err := httputil.FormSimilarityValue(r, "similarity", defaultSimilarity)
 
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	resultItems, err := h.spellchecker.Predict(query, topK, similarity)
+	resultItems, err := h.spellchecker.Predict(r.Context(), query, topK, similarity)
 
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"github.com/suggest-go/suggest/internal/grpc"
 	"github.com/suggest-go/suggest/internal/http"
 	"io/ioutil"
 	"log"
@@ -11,10 +12,13 @@ import (
 	"path/filepath"
 	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/suggest-go/suggest/pkg/suggest"
+	"github.com/suggest-go/suggest/pkg/suggestpb"
+	"golang.org/x/sync/errgroup"
 )
 
 // App is our application
@@ -27,6 +31,14 @@ type AppConfig struct {
 	Port       string
 	ConfigPath string
 	PidPath    string
+	// Timeout limits the search time of a request, the candidates found
+	// before the timeout are returned as a partial result
+	Timeout time.Duration
+	// BatchWorkers is the number of goroutines serving the queries of a batch request,
+	// GOMAXPROCS goroutines are used if it is not positive
+	BatchWorkers int
+	// GRPCPort is the listen port of the gRPC server, the gRPC server is not started if it is empty
+	GRPCPort string
 }
 
 // NewApp creates new instance of App for the given config
@@ -67,23 +79,53 @@ func (a App) Run() error {
 		)
 	}()
 
+	r := a.newRouter(suggestService, reindexJob)
+
+	corsHeaders := handlers.AllowedOrigins([]string{"*"})
+	corsMethods := handlers.AllowedMethods([]string{"GET", "POST"})
+	corsAllowedHeaders := handlers.AllowedHeaders([]string{"Content-Type"})
+
+	handler := handlers.LoggingHandler(os.Stdout, r)
+	handler = handlers.CORS(corsHeaders, corsMethods, corsAllowedHeaders)(handler)
+	httpServer := http.NewServer(handler, "0.0.0.0:"+a.config.Port)
+
+	if a.config.GRPCPort == "" {
+		return httpServer.Run(ctx)
+	}
+
+	grpcServer := grpc.NewServer("0.0.0.0:" + a.config.GRPCPort)
+	suggestpb.RegisterSuggestServer(grpcServer, &grpcHandler{
+		suggestService: suggestService,
+		timeout:        a.config.Timeout,
+		workers:        a.config.BatchWorkers,
+	})
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.Go(func() error {
+		return httpServer.Run(ctx)
+	})
+	group.Go(func() error {
+		return grpcServer.Run(ctx)
+	})
+
+	return group.Wait()
+}
+
+// newRouter returns the router of the HTTP API of the given service
+func (a App) newRouter(suggestService *suggest.Service, reindexJob func() error) *mux.Router {
 	r := mux.NewRouter()
 	r.StrictSlash(true)
 
 	r.HandleFunc("/", (&homeHandler{}).handle).Methods("GET")
-	r.HandleFunc("/autocomplete/{dict}/{query}/", (&autocompleteHandler{suggestService}).handle).Methods("GET")
-	r.HandleFunc("/suggest/{dict}/{query}/", (&suggestHandler{suggestService}).handle).Methods("GET")
+	r.HandleFunc("/autocomplete/{dict}/{query}/", (&autocompleteHandler{suggestService, a.config.Timeout}).handle).Methods("GET")
+	r.HandleFunc("/suggest/{dict}/batch", (&batchHandler{suggestService, a.config.Timeout, a.config.BatchWorkers}).handle).Methods("POST")
+	r.HandleFunc("/suggest/{dict}/{query}/", (&suggestHandler{suggestService, a.config.Timeout}).handle).Methods("GET")
+	r.HandleFunc("/autocomplete/{dict}/", (&jsonAutocompleteHandler{suggestService, a.config.Timeout}).handle).Methods("POST")
+	r.HandleFunc("/suggest/{dict}/", (&jsonSuggestHandler{suggestService, a.config.Timeout}).handle).Methods("POST")
 	r.HandleFunc("/dict/list/", (&dictionaryHandler{suggestService}).handle).Methods("GET")
 	r.HandleFunc("/internal/reindex/", (&reindexHandler{reindexJob}).handle).Methods("POST")
 
-	corsHeaders := handlers.AllowedOrigins([]string{"*"})
-	corsMethods := handlers.AllowedMethods([]string{"GET"})
-
-	handler := handlers.LoggingHandler(os.Stdout, r)
-	handler = handlers.CORS(corsHeaders, corsMethods)(handler)
-	httpServer := http.NewServer(handler, "0.0.0.0:"+a.config.Port)
-
-	return httpServer.Run(ctx)
+	return r
 }
 
 // writePIDFile performs writing a PID of the application service
@@ -114,13 +156,7 @@ func (a App) configureService(suggestService *suggest.Service) error {
 		return err
 	}
 
-	for _, config := range description {
-		if err := suggestService.AddIndexByDescription(config); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return suggestService.Reindex(description)
 }
 
 // listenToSystemSignals handles OS signals
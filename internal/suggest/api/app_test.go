@@ -0,0 +1,61 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+// testDictionary is the name of the dictionary of newTestService
+const testDictionary = "cars"
+
+// newTestService returns a service with the RAM index of a few cars named testDictionary
+func newTestService(t *testing.T) *suggest.Service {
+	dict := dictionary.NewInMemoryDictionary([]string{
+		"Nissan March",
+		"Nissan Juke",
+		"Nissan Maxima",
+		"Nissan Murano",
+		"Nissan Note",
+		"Toyota Mark II",
+		"Toyota Corolla",
+		"Toyota Corona",
+	})
+
+	description := suggest.IndexDescription{
+		Name:      testDictionary,
+		NGramSize: 3,
+		Wrap:      [2]string{"$", "$"},
+		Pad:       "$",
+		Alphabet:  []string{"english", "$"},
+	}
+
+	builder, err := suggest.NewRAMBuilder(dict, description)
+	assert.NoError(t, err)
+
+	service := suggest.NewService()
+	assert.NoError(t, service.AddIndex(description.Name, dict, builder))
+
+	return service
+}
+
+// serveTestRequest serves the request of the given method, target and body by the router of the test service
+func serveTestRequest(t *testing.T, method, target, body string) *httptest.ResponseRecorder {
+	router := NewApp(AppConfig{}).newRouter(newTestService(t), func() error { return nil })
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+	return recorder
+}
+
+func TestListDictionaries(t *testing.T) {
+	recorder := serveTestRequest(t, http.MethodGet, "/dict/list/", "")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `["cars"]`, recorder.Body.String())
+}
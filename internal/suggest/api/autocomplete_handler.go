@@ -1,9 +1,8 @@
 package api
 
 import (
-	"encoding/json"
-	httputil "github.com/suggest-go/suggest/internal/http"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/suggest-go/suggest/pkg/suggest"
@@ -12,41 +11,31 @@ import (
 // autocompleteHandler is responsible for query autocomplete
 type autocompleteHandler struct {
 	suggestService *suggest.Service
+	timeout        time.Duration
 }
 
-// handle performs autocomplete for the given query
+// handle performs autocomplete for the given query. The "ranking" parameter chooses the candidates to return,
+// the "match" one tells where the query is matched, i.e. "prefix", "word" or "infix",
+// the "typos" one is the number of typos to tolerate in the query
 func (h *autocompleteHandler) handle(w http.ResponseWriter, r *http.Request) {
-	var (
-		vars  = mux.Vars(r)
-		dict  = vars["dict"]
-		query = vars["query"]
-	)
-
-	topK, err := httputil.FormTopKValue(r, "topK", defaultTopK)
+	dict := mux.Vars(r)["dict"]
+	request, err := newFormSearchRequest(r)
 
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	resultItems, err := h.suggestService.Autocomplete(dict, query, topK)
+	topK, opts, err := request.autocompleteOptions()
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	data, err := json.Marshal(resultItems)
-
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	ctx, cancel := searchContext(r, h.timeout)
+	defer cancel()
 
-	if _, err := w.Write(data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	resultItems, err := h.suggestService.Autocomplete(ctx, dict, request.Query, topK, opts...)
+	writeSearchResult(w, resultItems, err)
 }
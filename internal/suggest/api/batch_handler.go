@@ -0,0 +1,155 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+const (
+	// maxBatchSize limits the number of queries of a batch request
+	maxBatchSize = 10000
+	// maxBatchBodySize limits the size of the JSON body of a batch request
+	maxBatchBodySize = 8 << 20
+)
+
+// batchQuery is a query of a batch request. The metric is required, the same as it is for the suggest handler,
+// the omitted topK and similarity take the default values of the suggest handler
+type batchQuery struct {
+	Query      string   `json:"query"`
+	TopK       *int     `json:"topK"`
+	Similarity *float64 `json:"similarity"`
+	Metric     string   `json:"metric"`
+}
+
+// batchResult is the result of a query of a batch response
+type batchResult struct {
+	Query string
+	Items []suggest.ResultItem
+	Error string `json:",omitempty"`
+}
+
+// batchHandler responses for handling batch suggest requests
+type batchHandler struct {
+	suggestService *suggest.Service
+	timeout        time.Duration
+	workers        int
+}
+
+// handle performs topK approximate string search for each query of the JSON array of the request body.
+// The results are written in the order of the queries, the error of a query is written along with its result.
+// The "weight", "alpha", "filter" and "layout" parameters of the URL are applied to all the queries
+func (h *batchHandler) handle(w http.ResponseWriter, r *http.Request) {
+	dict := mux.Vars(r)["dict"]
+	queries := []batchQuery{}
+
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodySize)).Decode(&queries); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode queries: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if len(queries) > maxBatchSize {
+		http.Error(w, fmt.Sprintf("batch should have at most %d queries", maxBatchSize), http.StatusBadRequest)
+		return
+	}
+
+	request, err := newFormSearchRequest(r)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := request.queryOptions()
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	configs := make([]suggest.SearchConfig, 0, len(queries))
+
+	for i, query := range queries {
+		searchConf, err := buildBatchSearchConfig(query, opts)
+
+		if err != nil {
+			http.Error(w, fmt.Sprintf("query %d: %v", i, err), http.StatusBadRequest)
+			return
+		}
+
+		configs = append(configs, searchConf)
+	}
+
+	ctx, cancel := searchContext(r, h.timeout)
+	defer cancel()
+
+	results, err := h.suggestService.SuggestBatch(ctx, dict, configs, h.workers)
+
+	if err != nil {
+		http.Error(w, err.Error(), searchErrorStatus(err, http.StatusInternalServerError))
+		return
+	}
+
+	response := make([]batchResult, 0, len(results))
+
+	for i, result := range results {
+		item := batchResult{
+			Query: queries[i].Query,
+			Items: result.Items,
+		}
+
+		switch {
+		case errors.Is(result.Err, context.DeadlineExceeded):
+			w.Header().Set(partialResultHeader, "true")
+		case errors.Is(result.Err, context.Canceled):
+			// the client has gone away, so nobody waits for the response
+			return
+		case result.Err != nil:
+			item.Error = result.Err.Error()
+		}
+
+		response = append(response, item)
+	}
+
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if _, err := w.Write(data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
+// buildBatchSearchConfig builds a search config for the given query of a batch
+func buildBatchSearchConfig(query batchQuery, opts []suggest.QueryOption) (suggest.SearchConfig, error) {
+	topK, similarity := defaultTopK, defaultSimilarity
+
+	if query.TopK != nil {
+		topK = *query.TopK
+	}
+
+	if query.Similarity != nil {
+		similarity = *query.Similarity
+	}
+
+	m, err := metric.GetMetric(query.Metric)
+
+	if err != nil {
+		return suggest.SearchConfig{}, err
+	}
+
+	return suggest.NewSearchConfig(query.Query, topK, m, similarity, opts...)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchHandler(t *testing.T) {
+	queries := []string{"nissan mar", "toyota cor", "nisan juke", "toyota mark", "nissan note"}
+	body := make([]string, 0, len(queries))
+
+	for i, query := range queries {
+		body = append(body, fmt.Sprintf(`{"query": %q, "topK": %d, "metric": "Cosine"}`, query, 1+i%2))
+	}
+
+	recorder := serveTestRequest(t, http.MethodPost, "/suggest/cars/batch", "["+strings.Join(body, ",")+"]")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	results := []batchResult{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &results))
+	assert.Len(t, results, len(queries))
+
+	// the results are written in the order of the queries, each one equals the result of its own request
+	for i, result := range results {
+		assert.Equal(t, queries[i], result.Query)
+		assert.Empty(t, result.Error)
+
+		single := serveTestRequest(t, http.MethodPost, "/suggest/cars/", body[i])
+		response := searchResponse{}
+		assert.NoError(t, json.Unmarshal(single.Body.Bytes(), &response))
+
+		assert.Len(t, result.Items, len(response.Results))
+
+		for j, item := range result.Items {
+			assert.Equal(t, response.Results[j].Value, item.Value)
+		}
+	}
+}
+
+func TestBatchHandlerFailures(t *testing.T) {
+	testCases := []struct {
+		name   string
+		target string
+		body   string
+		code   int
+	}{
+		{
+			name:   "malformed body",
+			target: "/suggest/cars/batch",
+			body:   `[{"query": "nissan"`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "missing metric",
+			target: "/suggest/cars/batch",
+			body:   `[{"query": "nissan", "metric": "Cosine"}, {"query": "toyota"}]`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "invalid url option",
+			target: "/suggest/cars/batch?alpha=much",
+			body:   `[{"query": "nissan", "metric": "Cosine"}]`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "too large body",
+			target: "/suggest/cars/batch",
+			body:   `[{"query": "` + strings.Repeat("a", maxBatchBodySize) + `", "metric": "Cosine"}]`,
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "unknown dictionary",
+			target: "/suggest/trucks/batch",
+			body:   `[{"query": "nissan", "metric": "Cosine"}]`,
+			code:   http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := serveTestRequest(t, http.MethodPost, testCase.target, testCase.body)
+
+			assert.Equal(t, testCase.code, recorder.Code)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/suggest-go/suggest/pkg/suggest"
+	"github.com/suggest-go/suggest/pkg/suggestpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcHandler implements the Suggest gRPC service, the requests are converted to the searchRequest
+// the same as the HTTP ones are, so all the APIs share the defaults and the validation
+type grpcHandler struct {
+	suggestpb.UnimplementedSuggestServer
+	suggestService *suggest.Service
+	timeout        time.Duration
+	workers        int
+}
+
+// Suggest performs topK approximate string search
+func (h *grpcHandler) Suggest(ctx context.Context, req *suggestpb.SuggestRequest) (*suggestpb.SearchResponse, error) {
+	searchConf, err := newSuggestSearchRequest(req).searchConfig()
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx, cancel := withSearchTimeout(ctx, h.timeout)
+	defer cancel()
+
+	resultItems, err := h.suggestService.Suggest(ctx, req.Dictionary, searchConf)
+
+	return newGRPCSearchResponse(resultItems, err)
+}
+
+// Autocomplete performs autocomplete for the given query
+func (h *grpcHandler) Autocomplete(ctx context.Context, req *suggestpb.AutocompleteRequest) (*suggestpb.SearchResponse, error) {
+	request := searchRequest{
+		Query:         req.Query,
+		TopK:          optionalInt(req.TopK),
+		Filters:       req.Filters,
+		Weight:        req.Weight,
+		Alpha:         optionalFloat(req.Alpha),
+		Layouts:       req.Layouts,
+		LayoutPenalty: optionalFloat(req.LayoutPenalty),
+		Ranking:       req.Ranking,
+		Match:         req.Match,
+		Typos:         int(req.Typos),
+	}
+
+	topK, opts, err := request.autocompleteOptions()
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ctx, cancel := withSearchTimeout(ctx, h.timeout)
+	defer cancel()
+
+	resultItems, err := h.suggestService.Autocomplete(ctx, req.Dictionary, req.Query, topK, opts...)
+
+	return newGRPCSearchResponse(resultItems, err)
+}
+
+// ListDictionaries returns the managed list of dictionaries
+func (h *grpcHandler) ListDictionaries(ctx context.Context, req *suggestpb.ListDictionariesRequest) (*suggestpb.ListDictionariesResponse, error) {
+	return &suggestpb.ListDictionariesResponse{
+		Dictionaries: h.suggestService.GetDictionaries(),
+	}, nil
+}
+
+// SuggestBatch performs topK approximate string search for each query of the batch and sends their results
+// in the order of the queries, each result is sent as soon as it and the results of the previous queries are ready
+func (h *grpcHandler) SuggestBatch(req *suggestpb.SuggestBatchRequest, stream suggestpb.Suggest_SuggestBatchServer) error {
+	if len(req.Queries) > maxBatchSize {
+		return status.Errorf(codes.InvalidArgument, "batch should have at most %d queries", maxBatchSize)
+	}
+
+	configs := make([]suggest.SearchConfig, 0, len(req.Queries))
+
+	for i, query := range req.Queries {
+		if query.Dictionary != "" && query.Dictionary != req.Dictionary {
+			return status.Errorf(codes.InvalidArgument, "query %d: dictionary %s differs from the batch one", i, query.Dictionary)
+		}
+
+		searchConf, err := newSuggestSearchRequest(query).searchConfig()
+
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "query %d: %v", i, err)
+		}
+
+		configs = append(configs, searchConf)
+	}
+
+	ctx, cancel := withSearchTimeout(stream.Context(), h.timeout)
+	defer cancel()
+
+	err := h.suggestService.SuggestBatchFunc(ctx, req.Dictionary, configs, h.workers, func(i int, result suggest.BatchResult) error {
+		response := &suggestpb.SuggestBatchResponse{
+			Index: int32(i),
+		}
+
+		switch {
+		case errors.Is(result.Err, context.DeadlineExceeded):
+			response.Partial = true
+		case errors.Is(result.Err, context.Canceled):
+			return status.Error(codes.Canceled, result.Err.Error())
+		case result.Err != nil:
+			response.Error = result.Err.Error()
+		}
+
+		items, err := newGRPCResultItems(result.Items)
+
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		response.Results = items
+
+		return stream.Send(response)
+	})
+
+	if errors.Is(err, suggest.ErrDictionaryNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+
+	return err
+}
+
+// newSuggestSearchRequest converts the gRPC suggest request to the searchRequest
+func newSuggestSearchRequest(req *suggestpb.SuggestRequest) searchRequest {
+	return searchRequest{
+		Query:         req.Query,
+		TopK:          optionalInt(req.TopK),
+		Similarity:    optionalFloat(req.Similarity),
+		Metric:        req.Metric,
+		Filters:       req.Filters,
+		Weight:        req.Weight,
+		Alpha:         optionalFloat(req.Alpha),
+		Layouts:       req.Layouts,
+		LayoutPenalty: optionalFloat(req.LayoutPenalty),
+		Words:         req.Words,
+		Explain:       req.Explain,
+		Phonetic:      req.Phonetic,
+	}
+}
+
+// newGRPCSearchResponse builds the response of a search finished with the given error.
+// If the search has been interrupted by the deadline, the candidates found so far are responded
+// and the response is flagged as a partial one
+func newGRPCSearchResponse(resultItems []suggest.ResultItem, err error) (*suggestpb.SearchResponse, error) {
+	response := &suggestpb.SearchResponse{}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		response.Partial = true
+	case errors.Is(err, context.Canceled):
+		return nil, status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, suggest.ErrDictionaryNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case err != nil:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if response.Results, err = newGRPCResultItems(resultItems); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return response, nil
+}
+
+// newGRPCResultItems converts the result items to the gRPC ones
+func newGRPCResultItems(resultItems []suggest.ResultItem) ([]*suggestpb.ResultItem, error) {
+	items := make([]*suggestpb.ResultItem, 0, len(resultItems))
+
+	for _, resultItem := range resultItems {
+		item := &suggestpb.ResultItem{
+			Score:      resultItem.Score,
+			Value:      resultItem.Value,
+			Payload:    resultItem.Payload,
+			Highlights: make([]*suggestpb.Highlight, 0, len(resultItem.Highlights)),
+		}
+
+		for _, highlight := range resultItem.Highlights {
+			item.Highlights = append(item.Highlights, &suggestpb.Highlight{
+				Start: int32(highlight.Start),
+				End:   int32(highlight.End),
+			})
+		}
+
+		if resultItem.Explanation != nil {
+			explanation, err := json.Marshal(resultItem.Explanation)
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode explanation: %w", err)
+			}
+
+			item.Explanation = explanation
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// optionalInt returns nil for the zero value of a gRPC field, so the default value is taken
+func optionalInt(value int32) *int {
+	if value == 0 {
+		return nil
+	}
+
+	v := int(value)
+
+	return &v
+}
+
+// optionalFloat returns nil for the zero value of a gRPC field, so the default value is taken
+func optionalFloat(value float64) *float64 {
+	if value == 0 {
+		return nil
+	}
+
+	return &value
+}
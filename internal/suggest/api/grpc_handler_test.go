@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/suggestpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves the gRPC handler of the test service over an in-memory connection
+// and returns the client of it
+func newTestClient(t *testing.T) suggestpb.SuggestClient {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	suggestpb.RegisterSuggestServer(server, &grpcHandler{
+		suggestService: newTestService(t),
+		workers:        2,
+	})
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithInsecure(),
+	)
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = conn.Close()
+		server.Stop()
+	})
+
+	return suggestpb.NewSuggestClient(conn)
+}
+
+func TestGRPCSuggest(t *testing.T) {
+	client := newTestClient(t)
+
+	response, err := client.Suggest(context.Background(), &suggestpb.SuggestRequest{
+		Dictionary: testDictionary,
+		Query:      "nissan mar",
+		TopK:       2,
+		Metric:     "Cosine",
+	})
+	assert.NoError(t, err)
+
+	values := make([]string, 0, len(response.Results))
+
+	for _, item := range response.Results {
+		values = append(values, item.Value)
+	}
+
+	assert.ElementsMatch(t, []string{"Nissan March", "Nissan Maxima"}, values)
+
+	_, err = client.Suggest(context.Background(), &suggestpb.SuggestRequest{
+		Dictionary: testDictionary,
+		Query:      "nissan",
+		Metric:     "Unknown",
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.Suggest(context.Background(), &suggestpb.SuggestRequest{
+		Dictionary: "trucks",
+		Query:      "nissan",
+		Metric:     "Cosine",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.Autocomplete(context.Background(), &suggestpb.AutocompleteRequest{
+		Dictionary: "trucks",
+		Query:      "nissan",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGRPCSuggestBatch(t *testing.T) {
+	client := newTestClient(t)
+	queries := []string{"nissan mar", "toyota cor", "nisan juke", "toyota mark", "nissan note"}
+	request := &suggestpb.SuggestBatchRequest{
+		Dictionary: testDictionary,
+	}
+
+	for _, query := range queries {
+		request.Queries = append(request.Queries, &suggestpb.SuggestRequest{
+			Query:  query,
+			Metric: "Cosine",
+		})
+	}
+
+	stream, err := client.SuggestBatch(context.Background(), request)
+	assert.NoError(t, err)
+
+	// the results are streamed in the order of the queries, each one equals the result of its own request
+	for i, query := range request.Queries {
+		response, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, int32(i), response.Index)
+		assert.Empty(t, response.Error)
+
+		expected, err := client.Suggest(context.Background(), &suggestpb.SuggestRequest{
+			Dictionary: testDictionary,
+			Query:      query.Query,
+			Metric:     query.Metric,
+		})
+		assert.NoError(t, err)
+		assert.Len(t, response.Results, len(expected.Results))
+
+		for j, item := range response.Results {
+			assert.Equal(t, expected.Results[j].Value, item.Value)
+		}
+	}
+
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	request.Dictionary = "trucks"
+	stream, err = client.SuggestBatch(context.Background(), request)
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	request.Dictionary = testDictionary
+	request.Queries[1].Metric = "Unknown"
+	stream, err = client.SuggestBatch(context.Background(), request)
+	assert.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,153 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+// maxRequestBodySize limits the size of the JSON body of a search request
+const maxRequestBodySize = 1 << 20
+
+// searchResponse is the response envelope of a POST search request, i.e.
+//
+//	{"results": [{"Score": 0.9, "Value": "..."}], "tookMs": 1.5, "partial": true}
+//
+// A failed request is responded with the empty results and the error, i.e.
+//
+//	{"results": [], "tookMs": 0.1, "error": "given dictionary cars is not exists: dictionary is not found"}
+type searchResponse struct {
+	// Results are the found result items
+	Results []suggest.ResultItem `json:"results"`
+	// TookMs is the search time in milliseconds
+	TookMs float64 `json:"tookMs"`
+	// Partial tells that the search has been interrupted by the request timeout
+	// and Results contains only the candidates found before it
+	Partial bool `json:"partial,omitempty"`
+	// Error describes why the request has failed
+	Error string `json:"error,omitempty"`
+}
+
+// jsonSuggestHandler responses for handling suggest requests with JSON bodies
+type jsonSuggestHandler struct {
+	suggestService *suggest.Service
+	timeout        time.Duration
+}
+
+// handle performs topK approximate string search for the searchRequest of the body
+func (h *jsonSuggestHandler) handle(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	request, err := decodeSearchRequest(w, r)
+
+	if err != nil {
+		writeSearchResponse(w, start, nil, err, http.StatusBadRequest)
+		return
+	}
+
+	searchConf, err := request.searchConfig()
+
+	if err != nil {
+		writeSearchResponse(w, start, nil, err, http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := searchContext(r, h.timeout)
+	defer cancel()
+
+	resultItems, err := h.suggestService.Suggest(ctx, mux.Vars(r)["dict"], searchConf)
+	writeSearchResponse(w, start, resultItems, err, http.StatusInternalServerError)
+}
+
+// jsonAutocompleteHandler responses for handling autocomplete requests with JSON bodies
+type jsonAutocompleteHandler struct {
+	suggestService *suggest.Service
+	timeout        time.Duration
+}
+
+// handle performs autocomplete for the searchRequest of the body
+func (h *jsonAutocompleteHandler) handle(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	request, err := decodeSearchRequest(w, r)
+
+	if err != nil {
+		writeSearchResponse(w, start, nil, err, http.StatusBadRequest)
+		return
+	}
+
+	topK, opts, err := request.autocompleteOptions()
+
+	if err != nil {
+		writeSearchResponse(w, start, nil, err, http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := searchContext(r, h.timeout)
+	defer cancel()
+
+	resultItems, err := h.suggestService.Autocomplete(ctx, mux.Vars(r)["dict"], request.Query, topK, opts...)
+	writeSearchResponse(w, start, resultItems, err, http.StatusInternalServerError)
+}
+
+// decodeSearchRequest decodes the searchRequest of the body of the given request
+func decodeSearchRequest(w http.ResponseWriter, r *http.Request) (searchRequest, error) {
+	request := searchRequest{}
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&request); err != nil {
+		return searchRequest{}, fmt.Errorf("failed to decode request: %w", err)
+	}
+
+	return request, nil
+}
+
+// writeSearchResponse writes the response envelope of a search started at the given time and finished
+// with the given error, the failed search is responded with the given status code or 404 for an unknown dictionary.
+// If the search has been interrupted by the timeout, the candidates found so far are written
+// and the response is flagged with the partialResultHeader
+func writeSearchResponse(w http.ResponseWriter, start time.Time, resultItems []suggest.ResultItem, err error, code int) {
+	response := searchResponse{
+		Results: resultItems,
+	}
+
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		w.Header().Set(partialResultHeader, "true")
+		response.Partial = true
+		code = http.StatusOK
+	case errors.Is(err, context.Canceled):
+		// the client has gone away, so nobody waits for the response
+		return
+	case err != nil:
+		response.Error = err.Error()
+		code = searchErrorStatus(err, code)
+	default:
+		code = http.StatusOK
+	}
+
+	if response.Results == nil {
+		response.Results = []suggest.ResultItem{}
+	}
+
+	response.TookMs = float64(time.Since(start)) / float64(time.Millisecond)
+	data, err := json.Marshal(response)
+
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	if _, err := w.Write(data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,115 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONSuggestHandler(t *testing.T) {
+	testCases := []struct {
+		name    string
+		target  string
+		body    string
+		code    int
+		values  []string
+		failure string
+	}{
+		{
+			name:   "suggest",
+			target: "/suggest/cars/",
+			body:   `{"query": "nissan mar", "topK": 2, "metric": "Cosine"}`,
+			code:   http.StatusOK,
+			values: []string{"Nissan March", "Nissan Maxima"},
+		},
+		{
+			name:   "autocomplete",
+			target: "/autocomplete/cars/",
+			body:   `{"query": "toyota cor", "topK": 5}`,
+			code:   http.StatusOK,
+			values: []string{"Toyota Corolla", "Toyota Corona"},
+		},
+		{
+			name:    "malformed body",
+			target:  "/suggest/cars/",
+			body:    `{"query": "nissan"`,
+			code:    http.StatusBadRequest,
+			failure: "failed to decode request",
+		},
+		{
+			name:    "unknown field",
+			target:  "/suggest/cars/",
+			body:    `{"query": "nissan", "metric": "Cosine", "limit": 5}`,
+			code:    http.StatusBadRequest,
+			failure: "unknown field",
+		},
+		{
+			name:    "unknown metric",
+			target:  "/suggest/cars/",
+			body:    `{"query": "nissan", "metric": "Unknown"}`,
+			code:    http.StatusBadRequest,
+			failure: "Unknown",
+		},
+		{
+			name:    "unknown suggest dictionary",
+			target:  "/suggest/trucks/",
+			body:    `{"query": "nissan", "metric": "Cosine"}`,
+			code:    http.StatusNotFound,
+			failure: "dictionary is not found",
+		},
+		{
+			name:    "unknown autocomplete dictionary",
+			target:  "/autocomplete/trucks/",
+			body:    `{"query": "nissan"}`,
+			code:    http.StatusNotFound,
+			failure: "dictionary is not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := serveTestRequest(t, http.MethodPost, testCase.target, testCase.body)
+
+			assert.Equal(t, testCase.code, recorder.Code)
+			assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+			// the envelope has the documented keys, the results are never null
+			envelope := map[string]json.RawMessage{}
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &envelope))
+			assert.Contains(t, envelope, "results")
+			assert.Contains(t, envelope, "tookMs")
+			assert.NotContains(t, envelope, "partial")
+
+			response := searchResponse{}
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+			assert.NotNil(t, response.Results)
+
+			values := make([]string, 0, len(response.Results))
+
+			for _, item := range response.Results {
+				values = append(values, item.Value)
+			}
+
+			if testCase.values != nil {
+				assert.ElementsMatch(t, testCase.values, values)
+			}
+
+			if testCase.failure == "" {
+				assert.Empty(t, response.Error)
+			} else {
+				assert.Empty(t, values)
+				assert.True(t, strings.Contains(response.Error, testCase.failure), response.Error)
+			}
+		})
+	}
+}
+
+func TestJSONSuggestHandlerBodySize(t *testing.T) {
+	body := `{"query": "` + strings.Repeat("a", maxRequestBodySize) + `", "metric": "Cosine"}`
+	recorder := serveTestRequest(t, http.MethodPost, "/suggest/cars/", body)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+}
@@ -0,0 +1,426 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	httputil "github.com/suggest-go/suggest/internal/http"
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+// searchRequest describes a suggest or an autocomplete request of any API, so all of them share the defaults
+// and the validation. It is the JSON body of the POST /suggest/{dict}/ and /autocomplete/{dict}/ requests,
+// the omitted fields take the default values, i.e.
+//
+//	{
+//	  "query": "nissan mar",
+//	  "topK": 5,
+//	  "similarity": 0.5,
+//	  "metric": "Cosine",
+//	  "filters": ["category=sedan", "brand in (bmw, audi)"],
+//	  "weight": "linear",
+//	  "alpha": 0.3,
+//	  "layouts": ["jcuken-qwerty"],
+//	  "layoutPenalty": 0.9,
+//	  "words": "all",
+//	  "explain": true,
+//	  "phonetic": 0.3,
+//	  "rerank": {"distance": "levenshtein", "topN": 20, "maxDistance": 2, "substituteCost": 1}
+//	}
+//
+// for a suggest request and
+//
+//	{"query": "nissan mar", "topK": 5, "ranking": "weight", "match": "word", "typos": 1}
+//
+// for an autocomplete one
+type searchRequest struct {
+	// Query is the search query
+	Query string `json:"query"`
+	// TopK is the maximum number of the results
+	TopK *int `json:"topK"`
+	// Similarity is the minimal similarity of a suggest result
+	Similarity *float64 `json:"similarity"`
+	// Metric is the similarity metric of a suggest request, it is required for a suggest request
+	Metric string `json:"metric"`
+	// Filters are the filter clauses, that all the results should satisfy
+	Filters []string `json:"filters"`
+	// Weight is the name of the weight formula, that ranks the results
+	Weight string `json:"weight"`
+	// Alpha tunes the weight formula
+	Alpha *float64 `json:"alpha"`
+	// Layouts are the keyboard layouts to remap the query through
+	Layouts []string `json:"layouts"`
+	// LayoutPenalty is the score multiplier of the results found by a remapped query
+	LayoutPenalty *float64 `json:"layoutPenalty"`
+	// Words makes the query of a suggest request match each word separately with "all" or "any" semantics
+	Words string `json:"words"`
+	// Explain attaches the explanation of its score to each suggest result
+	Explain bool `json:"explain"`
+	// Phonetic is the boost of the phonetic-match signal of a suggest request
+	Phonetic float64 `json:"phonetic"`
+	// Rerank re-scores the suggest results by an edit distance
+	Rerank *rerankRequest `json:"rerank"`
+	// Ranking chooses the autocomplete results to return
+	Ranking string `json:"ranking"`
+	// Match tells where the autocomplete query is matched, i.e. "prefix", "word" or "infix"
+	Match string `json:"match"`
+	// Typos is the number of typos to tolerate in the autocomplete query
+	Typos int `json:"typos"`
+}
+
+// rerankRequest describes the edit distance reranking of a suggest request
+type rerankRequest struct {
+	// Distance is the name of the edit distance
+	Distance string `json:"distance"`
+	// TopN is the number of the candidates to re-score
+	TopN *int `json:"topN"`
+	// MaxDistance drops the candidates, that are too far from the query
+	MaxDistance float64 `json:"maxDistance"`
+	// InsertCost, DeleteCost, SubstituteCost and TransposeCost are the costs of the edit operations
+	InsertCost     *float64 `json:"insertCost"`
+	DeleteCost     *float64 `json:"deleteCost"`
+	SubstituteCost *float64 `json:"substituteCost"`
+	TransposeCost  *float64 `json:"transposeCost"`
+}
+
+// newFormSearchRequest builds the searchRequest of the parameters of a GET request. The "words" parameter
+// makes the query match each word separately with "all" or "any" semantics, "explain=true" attaches
+// the explanation of its score to each result item and the "phonetic" one is the boost of the phonetic-match
+// signal. The weight formula is chosen by the "weight" parameter and tuned by the "alpha" one.
+// Each "filter" parameter is a filter clause, i.e. "category=sedan" or "brand in (bmw, audi)".
+// Each "layout" parameter is a keyboard layout to remap the query through, i.e. "jcuken-qwerty",
+// the candidates of the remapped queries are penalised by the "layoutPenalty" parameter.
+// The edit distance reranking is chosen by the "rerank" parameter, the operation costs are tuned by
+// the "insertCost", "deleteCost", "substituteCost" and "transposeCost" ones. The "rerankTopN" parameter
+// is the number of candidates to re-score and the "maxDistance" one drops the candidates, that are too far
+// from the query. The "ranking" parameter chooses the autocomplete candidates to return, the "match" one
+// tells where the autocomplete query is matched and the "typos" one is the number of typos to tolerate
+func newFormSearchRequest(r *http.Request) (searchRequest, error) {
+	if err := r.ParseForm(); err != nil {
+		return searchRequest{}, err
+	}
+
+	request := searchRequest{
+		Query:   mux.Vars(r)["query"],
+		Metric:  r.FormValue("metric"),
+		Filters: r.Form["filter"],
+		Weight:  r.FormValue("weight"),
+		Layouts: r.Form["layout"],
+		Words:   r.FormValue("words"),
+		Explain: r.FormValue("explain") == "true",
+		Ranking: r.FormValue("ranking"),
+		Match:   r.FormValue("match"),
+	}
+
+	var err error
+
+	if request.TopK, err = formOptionalInt(r, "topK"); err != nil {
+		return searchRequest{}, err
+	}
+
+	if request.Similarity, err = formOptionalFloat(r, "similarity"); err != nil {
+		return searchRequest{}, err
+	}
+
+	if request.Alpha, err = formOptionalFloat(r, "alpha"); err != nil {
+		return searchRequest{}, err
+	}
+
+	if request.LayoutPenalty, err = formOptionalFloat(r, "layoutPenalty"); err != nil {
+		return searchRequest{}, err
+	}
+
+	if request.Phonetic, err = httputil.FormFloatValue(r, "phonetic", 0); err != nil {
+		return searchRequest{}, err
+	}
+
+	if request.Typos, err = httputil.FormIntValue(r, "typos", 0); err != nil {
+		return searchRequest{}, err
+	}
+
+	if distance := r.FormValue("rerank"); distance != "" {
+		if request.Rerank, err = newFormRerankRequest(r, distance); err != nil {
+			return searchRequest{}, err
+		}
+	}
+
+	return request, nil
+}
+
+// newFormRerankRequest builds the rerankRequest of the parameters of a GET request
+func newFormRerankRequest(r *http.Request, distance string) (*rerankRequest, error) {
+	rerank := &rerankRequest{
+		Distance: distance,
+	}
+
+	var err error
+
+	if rerank.TopN, err = formOptionalInt(r, "rerankTopN"); err != nil {
+		return nil, err
+	}
+
+	if rerank.MaxDistance, err = httputil.FormFloatValue(r, "maxDistance", 0); err != nil {
+		return nil, err
+	}
+
+	fields := []struct {
+		name string
+		cost **float64
+	}{
+		{"insertCost", &rerank.InsertCost},
+		{"deleteCost", &rerank.DeleteCost},
+		{"substituteCost", &rerank.SubstituteCost},
+		{"transposeCost", &rerank.TransposeCost},
+	}
+
+	for _, field := range fields {
+		if *field.cost, err = formOptionalFloat(r, field.name); err != nil {
+			return nil, err
+		}
+	}
+
+	return rerank, nil
+}
+
+// formOptionalInt returns the integer value of the named parameter, or nil if the parameter is omitted
+func formOptionalInt(r *http.Request, field string) (*int, error) {
+	if r.FormValue(field) == "" {
+		return nil, nil
+	}
+
+	value, err := httputil.FormIntValue(r, field, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// formOptionalFloat returns the float value of the named parameter, or nil if the parameter is omitted
+func formOptionalFloat(r *http.Request, field string) (*float64, error) {
+	if r.FormValue(field) == "" {
+		return nil, nil
+	}
+
+	value, err := httputil.FormFloatValue(r, field, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// searchConfig builds a search config of the suggest request
+func (s searchRequest) searchConfig() (suggest.SearchConfig, error) {
+	topK, err := s.topK()
+
+	if err != nil {
+		return suggest.SearchConfig{}, err
+	}
+
+	m, err := metric.GetMetric(s.Metric)
+
+	if err != nil {
+		return suggest.SearchConfig{}, err
+	}
+
+	similarity := defaultSimilarity
+
+	if s.Similarity != nil {
+		similarity = *s.Similarity
+	}
+
+	opts, err := s.queryOptions()
+
+	if err != nil {
+		return suggest.SearchConfig{}, err
+	}
+
+	if s.Words != "" {
+		matching, err := suggest.ParseWordMatching(s.Words)
+
+		if err != nil {
+			return suggest.SearchConfig{}, err
+		}
+
+		opts = append(opts, suggest.WithWordMatching(matching))
+	}
+
+	if s.Explain {
+		opts = append(opts, suggest.WithExplain())
+	}
+
+	if s.Phonetic != 0 {
+		opts = append(opts, suggest.WithPhoneticBoost(s.Phonetic))
+	}
+
+	if s.Rerank != nil {
+		reranking, err := s.Rerank.reranking(topK)
+
+		if err != nil {
+			return suggest.SearchConfig{}, err
+		}
+
+		opts = append(opts, suggest.WithEditDistanceReranking(reranking))
+	}
+
+	return suggest.NewSearchConfig(s.Query, topK, m, similarity, opts...)
+}
+
+// autocompleteOptions returns the limit and the options of the autocomplete request
+func (s searchRequest) autocompleteOptions() (int, []suggest.QueryOption, error) {
+	topK, err := s.topK()
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	opts, err := s.queryOptions()
+
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if s.Ranking != "" {
+		ranking, err := suggest.ParseAutocompleteRanking(s.Ranking)
+
+		if err != nil {
+			return 0, nil, err
+		}
+
+		opts = append(opts, suggest.WithAutocompleteRanking(ranking))
+	}
+
+	if s.Match != "" {
+		matching, err := suggest.ParseAutocompleteMatching(s.Match)
+
+		if err != nil {
+			return 0, nil, err
+		}
+
+		opts = append(opts, suggest.WithAutocompleteMatching(matching))
+	}
+
+	if s.Typos != 0 {
+		opts = append(opts, suggest.WithAutocompleteTypos(s.Typos))
+	}
+
+	return topK, opts, nil
+}
+
+// topK returns the maximum number of the results of the request
+func (s searchRequest) topK() (int, error) {
+	if s.TopK == nil {
+		return defaultTopK, nil
+	}
+
+	if *s.TopK < 0 {
+		return 0, errors.New("topK should be positive integer")
+	}
+
+	return *s.TopK, nil
+}
+
+// queryOptions builds the filter, the keyboard layouts and the weight formula of the request
+func (s searchRequest) queryOptions() ([]suggest.QueryOption, error) {
+	opts := []suggest.QueryOption{}
+
+	if len(s.Filters) > 0 {
+		clauses := make([]suggest.FilterClause, 0, len(s.Filters))
+
+		for _, expr := range s.Filters {
+			clause, err := suggest.ParseFilterClause(expr)
+
+			if err != nil {
+				return nil, err
+			}
+
+			clauses = append(clauses, clause)
+		}
+
+		opts = append(opts, suggest.WithFilter(clauses...))
+	}
+
+	if len(s.Layouts) > 0 {
+		layouts := make([]analysis.KeyboardLayout, 0, len(s.Layouts))
+
+		for _, name := range s.Layouts {
+			layout, err := analysis.GetKeyboardLayout(name)
+
+			if err != nil {
+				return nil, err
+			}
+
+			layouts = append(layouts, layout)
+		}
+
+		penalty := defaultLayoutPenalty
+
+		if s.LayoutPenalty != nil {
+			penalty = *s.LayoutPenalty
+		}
+
+		opts = append(opts, suggest.WithKeyboardLayouts(penalty, layouts...))
+	}
+
+	if s.Weight == "" {
+		return opts, nil
+	}
+
+	alpha := defaultWeightAlpha
+
+	if s.Alpha != nil {
+		alpha = *s.Alpha
+	}
+
+	formula, err := suggest.GetWeightFormula(s.Weight, alpha)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(opts, suggest.WithWeightFormula(formula)), nil
+}
+
+// reranking builds the edit distance reranking of the request
+func (r rerankRequest) reranking(topK int) (suggest.EditDistanceReranking, error) {
+	costs := metric.DefaultEditCosts
+	fields := []struct {
+		value *float64
+		cost  *float64
+	}{
+		{r.InsertCost, &costs.Insert},
+		{r.DeleteCost, &costs.Delete},
+		{r.SubstituteCost, &costs.Substitute},
+		{r.TransposeCost, &costs.Transpose},
+	}
+
+	for _, field := range fields {
+		if field.value != nil {
+			*field.cost = *field.value
+		}
+	}
+
+	distance, err := metric.GetEditDistance(r.Distance, costs)
+
+	if err != nil {
+		return suggest.EditDistanceReranking{}, err
+	}
+
+	topN := defaultRerankFactor * topK
+
+	if r.TopN != nil {
+		topN = *r.TopN
+	}
+
+	return suggest.EditDistanceReranking{
+		Distance:    distance,
+		TopN:        topN,
+		MaxDistance: r.MaxDistance,
+	}, nil
+}
@@ -1,101 +1,113 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"github.com/gorilla/mux"
-	httputil "github.com/suggest-go/suggest/internal/http"
-	"github.com/suggest-go/suggest/pkg/metric"
 	"github.com/suggest-go/suggest/pkg/suggest"
 	"net/http"
+	"time"
 )
 
 const (
-	jaccard = "Jaccard"
-	cosine  = "Cosine"
-	dice    = "Dice"
-	exact   = "Exact"
-	overlap = "Overlap"
-
-	defaultSimilarity = 0.5
-	defaultTopK = 5
+	defaultSimilarity  = 0.5
+	defaultTopK        = 5
+	defaultWeightAlpha = 0.3
+	// defaultRerankFactor tells how many times more candidates than topK are re-scored by default
+	defaultRerankFactor = 4
+	// defaultLayoutPenalty is a score multiplier of the candidates found by a keyboard layout remapped query
+	defaultLayoutPenalty = 0.9
+
+	// partialResultHeader tells that the search has been interrupted by the request timeout
+	// and the response contains only the candidates found before it
+	partialResultHeader = "X-Partial-Result"
 )
 
-var metrics map[string]metric.Metric
-
-func init() {
-	metrics = map[string]metric.Metric{
-		jaccard: metric.JaccardMetric(),
-		cosine:  metric.CosineMetric(),
-		dice:    metric.DiceMetric(),
-		exact:   metric.ExactMetric(),
-		overlap: metric.OverlapMetric(),
-	}
-}
-
 // suggestHandler responses for handling suggest requests
 type suggestHandler struct {
 	suggestService *suggest.Service
+	timeout        time.Duration
 }
 
 // handle performs topK approximate string search
 func (h *suggestHandler) handle(w http.ResponseWriter, r *http.Request) {
 	var (
-		vars       = mux.Vars(r)
-		dict       = vars["dict"]
+		vars = mux.Vars(r)
+		dict = vars["dict"]
 	)
 
-	searchConf, err := buildSearchConfig(r)
+	request, err := newFormSearchRequest(r)
 
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	// TODO return 4** on dictionary not found
-	resultItems, err := h.suggestService.Suggest(dict, searchConf)
+	searchConf, err := request.searchConfig()
 
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	data, err := json.Marshal(resultItems)
+	ctx, cancel := searchContext(r, h.timeout)
+	defer cancel()
 
-	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
+	resultItems, err := h.suggestService.Suggest(ctx, dict, searchConf)
+	writeSearchResult(w, resultItems, err)
+}
 
-	w.Header().Set("Content-Type", "application/json")
+// searchContext returns the context of the given request, which is limited by the timeout if it is set
+func searchContext(r *http.Request, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return withSearchTimeout(r.Context(), timeout)
+}
 
-	if _, err := w.Write(data); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+// withSearchTimeout returns the context limited by the timeout if it is set
+func withSearchTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
 	}
-}
 
-// buildSearchConfig builds a search config for the given list of parameters
-func buildSearchConfig(r *http.Request) (suggest.SearchConfig, error) {
-	vars := mux.Vars(r)
-	topK, err := httputil.FormTopKValue(r, "topK", defaultTopK)
+	return context.WithTimeout(ctx, timeout)
+}
 
-	if err != nil {
-		return suggest.SearchConfig{}, err
+// searchErrorStatus returns the status code of a search failed with the given error,
+// a search in an unknown dictionary is responded with 404, the other errors with the given code
+func searchErrorStatus(err error, code int) int {
+	if errors.Is(err, suggest.ErrDictionaryNotFound) {
+		return http.StatusNotFound
 	}
 
-	metricName := r.FormValue("metric")
-	m, ok := metrics[metricName]
+	return code
+}
 
-	if !ok {
-		return suggest.SearchConfig{}, errors.New("metric is not found")
+// writeSearchResult writes the result items of a search finished with the given error.
+// If the search has been interrupted by the timeout, the candidates found so far are written
+// and the response is flagged with the partialResultHeader
+func writeSearchResult(w http.ResponseWriter, resultItems []suggest.ResultItem, err error) {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		w.Header().Set(partialResultHeader, "true")
+	case errors.Is(err, context.Canceled):
+		// the client has gone away, so nobody waits for the response
+		return
+	case err != nil:
+		http.Error(w, err.Error(), searchErrorStatus(err, http.StatusInternalServerError))
+		return
 	}
 
-	similarity, err := httputil.FormSimilarityValue(r, "similarity", defaultSimilarity)
+	data, err := json.Marshal(resultItems)
 
 	if err != nil {
-		return suggest.SearchConfig{}, err
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	return suggest.NewSearchConfig(vars["query"], topK, m, similarity)
+	w.Header().Set("Content-Type", "application/json")
+
+	if _, err := w.Write(data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
 }
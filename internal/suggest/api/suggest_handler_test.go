@@ -0,0 +1,75 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/suggest"
+)
+
+func TestSuggestHandler(t *testing.T) {
+	testCases := []struct {
+		name   string
+		target string
+		code   int
+		values []string
+	}{
+		{
+			name:   "suggest",
+			target: "/suggest/cars/nissan%20mar/?metric=Cosine&topK=2",
+			code:   http.StatusOK,
+			values: []string{"Nissan March", "Nissan Maxima"},
+		},
+		{
+			name:   "autocomplete",
+			target: "/autocomplete/cars/toyota%20cor/?topK=5",
+			code:   http.StatusOK,
+			values: []string{"Toyota Corolla", "Toyota Corona"},
+		},
+		{
+			name:   "invalid topK",
+			target: "/suggest/cars/nissan/?metric=Cosine&topK=many",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "unknown metric",
+			target: "/suggest/cars/nissan/?metric=Unknown",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "unknown suggest dictionary",
+			target: "/suggest/trucks/nissan/?metric=Cosine",
+			code:   http.StatusNotFound,
+		},
+		{
+			name:   "unknown autocomplete dictionary",
+			target: "/autocomplete/trucks/nissan/",
+			code:   http.StatusNotFound,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := serveTestRequest(t, http.MethodGet, testCase.target, "")
+
+			assert.Equal(t, testCase.code, recorder.Code)
+
+			if testCase.code != http.StatusOK {
+				return
+			}
+
+			resultItems := []suggest.ResultItem{}
+			assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resultItems))
+
+			values := make([]string, 0, len(resultItems))
+
+			for _, item := range resultItems {
+				values = append(values, item.Value)
+			}
+
+			assert.ElementsMatch(t, testCase.values, values)
+		})
+	}
+}
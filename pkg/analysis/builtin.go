@@ -0,0 +1,214 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/suggest-go/suggest/pkg/alphabet"
+	"github.com/suggest-go/suggest/pkg/analysis/en"
+	"github.com/suggest-go/suggest/pkg/analysis/ru"
+)
+
+// defaultWordAlphabet is used by the word tokenizer, which definition has no alphabet
+var defaultWordAlphabet = []string{"english", "russian", "numbers"}
+
+func init() {
+	RegisterTokenizer("word", newWordTokenizerFromDefinition)
+	RegisterTokenizer("ngram", newNGramTokenizerFromDefinition)
+
+	RegisterFilter("lowercase", newLowercaseFilterFromDefinition)
+	RegisterFilter("alphabet", newNormalizerFilterFromDefinition)
+	RegisterFilter("normalization", newUnicodeNormalizerFilterFromDefinition)
+	RegisterFilter("transliteration", newTransliterationFilterFromDefinition)
+	RegisterFilter("keyboardLayout", newKeyboardLayoutFilterFromDefinition)
+	RegisterFilter("stemmer", newStemmerFilterFromDefinition)
+	RegisterFilter("stopWords", newStopWordsFilterFromDefinition)
+	RegisterFilter("phonetic", newPhoneticFilterFromDefinition)
+}
+
+// newWordTokenizerFromDefinition creates a word tokenizer from {"type": "word", "alphabet": ["english"]}
+func newWordTokenizerFromDefinition(definition Definition) (Tokenizer, error) {
+	params := struct {
+		Alphabet []string `json:"alphabet"`
+	}{
+		Alphabet: defaultWordAlphabet,
+	}
+
+	if err := definition.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	return NewWordTokenizer(alphabet.CreateAlphabet(params.Alphabet)), nil
+}
+
+// newNGramTokenizerFromDefinition creates a n-gram tokenizer from {"type": "ngram", "size": 3}
+func newNGramTokenizerFromDefinition(definition Definition) (Tokenizer, error) {
+	params := struct {
+		Size int `json:"size"`
+	}{}
+
+	if err := definition.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	if params.Size < 1 || params.Size > maxN {
+		return nil, fmt.Errorf("n-gram size should be in [1, %d], got %d", maxN, params.Size)
+	}
+
+	return NewNGramTokenizer(params.Size), nil
+}
+
+// newLowercaseFilterFromDefinition creates a filter, that lowercases each token, from {"type": "lowercase"}
+func newLowercaseFilterFromDefinition(definition Definition) (TokenFilter, error) {
+	return NewLowercaseFilter(), nil
+}
+
+// newNormalizerFilterFromDefinition creates a normalizer filter from {"type": "alphabet", "alphabet": ["english"], "pad": "$"}
+func newNormalizerFilterFromDefinition(definition Definition) (TokenFilter, error) {
+	params := struct {
+		Alphabet []string `json:"alphabet"`
+		Pad      string   `json:"pad"`
+	}{}
+
+	if err := definition.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	if len(params.Alphabet) == 0 {
+		return nil, fmt.Errorf("alphabet should not be empty")
+	}
+
+	return NewNormalizerFilter(alphabet.CreateAlphabet(params.Alphabet), params.Pad), nil
+}
+
+// newUnicodeNormalizerFilterFromDefinition creates a unicode normalizer filter from
+// {"type": "normalization", "caseFolding": true, "stripDiacritics": true, "mappings": {"ё": "е"}}
+func newUnicodeNormalizerFilterFromDefinition(definition Definition) (TokenFilter, error) {
+	normalization := UnicodeNormalization{}
+
+	if err := definition.Decode(&normalization); err != nil {
+		return nil, err
+	}
+
+	return NewUnicodeNormalizerFilter(normalization)
+}
+
+// newTransliterationFilterFromDefinition creates a transliteration filter from {"type": "transliteration", "scheme": "gost"}
+func newTransliterationFilterFromDefinition(definition Definition) (TokenFilter, error) {
+	params := struct {
+		Scheme string `json:"scheme"`
+	}{}
+
+	if err := definition.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	transliteration, err := GetTransliteration(params.Scheme)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTransliterationFilter(transliteration), nil
+}
+
+// newKeyboardLayoutFilterFromDefinition creates a keyboard layout filter from {"type": "keyboardLayout", "layout": "jcuken-qwerty"}
+func newKeyboardLayoutFilterFromDefinition(definition Definition) (TokenFilter, error) {
+	params := struct {
+		Layout string `json:"layout"`
+	}{}
+
+	if err := definition.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	layout, err := GetKeyboardLayout(params.Layout)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeyboardLayoutFilter(layout), nil
+}
+
+// newStemmerFilterFromDefinition creates a stemmer filter from {"type": "stemmer", "lang": "en"}
+func newStemmerFilterFromDefinition(definition Definition) (TokenFilter, error) {
+	params := struct {
+		Lang string `json:"lang"`
+	}{}
+
+	if err := definition.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	switch params.Lang {
+	case "en":
+		return NewEnglishStemmerFilter(), nil
+	case "ru":
+		return NewRussianStemmerFilter(), nil
+	default:
+		return nil, fmt.Errorf("stemmer for language %q is not supported", params.Lang)
+	}
+}
+
+// newStopWordsFilterFromDefinition creates a stop words filter from {"type": "stopWords", "lang": "en", "words": ["foo"]},
+// the given words are added to the stop words of the language
+func newStopWordsFilterFromDefinition(definition Definition) (TokenFilter, error) {
+	params := struct {
+		Lang  string   `json:"lang"`
+		Words []string `json:"words"`
+	}{}
+
+	if err := definition.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	words := append([]string(nil), params.Words...)
+
+	switch params.Lang {
+	case "":
+	case "en":
+		words = append(words, en.StopWords...)
+	case "ru":
+		words = append(words, ru.StopWords...)
+	default:
+		return nil, fmt.Errorf("stop words for language %q are not supported", params.Lang)
+	}
+
+	return NewStopWordsFilter(words), nil
+}
+
+// newPhoneticFilterFromDefinition creates a phonetic filter from {"type": "phonetic", "encoder": "doubleMetaphone"}
+func newPhoneticFilterFromDefinition(definition Definition) (TokenFilter, error) {
+	params := struct {
+		Encoder string `json:"encoder"`
+	}{}
+
+	if err := definition.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	encoder, err := GetPhoneticEncoder(params.Encoder)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewPhoneticFilter(encoder), nil
+}
+
+type lowercaseFilter struct{}
+
+// NewLowercaseFilter returns a tokens filter, that lowercases each token
+func NewLowercaseFilter() TokenFilter {
+	return &lowercaseFilter{}
+}
+
+// Filter filters the given list with described behaviour
+func (f *lowercaseFilter) Filter(list []Token) []Token {
+	for i, token := range list {
+		list[i] = strings.ToLower(token)
+	}
+
+	return list
+}
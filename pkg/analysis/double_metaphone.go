@@ -0,0 +1,667 @@
+package analysis
+
+import (
+	"strings"
+)
+
+// DefaultMetaphoneLength is the length of Double Metaphone keys proposed by the author of the algorithm
+const DefaultMetaphoneLength = 4
+
+var (
+	silentStarts      = []string{"GN", "KN", "PN", "WR", "PS"}
+	lrnmbhfvwSpace    = []string{"L", "R", "N", "M", "B", "H", "F", "V", "W", " "}
+	esEpEbElEyIbIlEtc = []string{"ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER"}
+	ltksnmbz          = []string{"L", "T", "K", "S", "N", "M", "B", "Z"}
+)
+
+// doubleMetaphone implements Double Metaphone algorithm by Lawrence Philips, that encodes
+// an English word into the primary and the alternate keys of its pronunciation
+type doubleMetaphone struct {
+	maxLength int
+}
+
+// NewDoubleMetaphoneEncoder creates a new Double Metaphone encoder, that produces the keys of at most maxLength characters
+func NewDoubleMetaphoneEncoder(maxLength int) PhoneticEncoder {
+	if maxLength <= 0 {
+		maxLength = DefaultMetaphoneLength
+	}
+
+	return &doubleMetaphone{
+		maxLength: maxLength,
+	}
+}
+
+// metaphoneResult accumulates the primary and the alternate keys
+type metaphoneResult struct {
+	primary, alternate strings.Builder
+	maxLength          int
+}
+
+func (r *metaphoneResult) append(primary, alternate string) {
+	r.appendPrimary(primary)
+	r.appendAlternate(alternate)
+}
+
+func (r *metaphoneResult) appendBoth(value string) {
+	r.append(value, value)
+}
+
+func (r *metaphoneResult) appendPrimary(value string) {
+	if r.primary.Len() < r.maxLength {
+		r.primary.WriteString(value)
+	}
+}
+
+func (r *metaphoneResult) appendAlternate(value string) {
+	if r.alternate.Len() < r.maxLength {
+		r.alternate.WriteString(value)
+	}
+}
+
+func (r *metaphoneResult) isComplete() bool {
+	return r.primary.Len() >= r.maxLength && r.alternate.Len() >= r.maxLength
+}
+
+// keys returns the distinct non-empty keys truncated to maxLength
+func (r *metaphoneResult) keys() []string {
+	keys := []string{}
+
+	for _, key := range []string{r.primary.String(), r.alternate.String()} {
+		if len(key) > r.maxLength {
+			key = key[:r.maxLength]
+		}
+
+		if key != "" && (len(keys) == 0 || keys[0] != key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// metaphoneWord is an uppercased word being encoded
+type metaphoneWord []rune
+
+// at returns the character at the given position or 0, if it is out of the word
+func (w metaphoneWord) at(i int) rune {
+	if i < 0 || i >= len(w) {
+		return 0
+	}
+
+	return w[i]
+}
+
+// contains tells whether the substring of the given length starting at start equals one of the criteria
+func (w metaphoneWord) contains(start, length int, criteria ...string) bool {
+	if start < 0 || start+length > len(w) {
+		return false
+	}
+
+	target := string(w[start : start+length])
+
+	for _, c := range criteria {
+		if target == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (w metaphoneWord) isVowel(i int) bool {
+	return strings.ContainsRune("AEIOUY", w.at(i))
+}
+
+func (w metaphoneWord) last() int {
+	return len(w) - 1
+}
+
+// Encode returns the primary and the alternate keys of the given word, the alternate
+// key is omitted if it equals to the primary one
+func (d *doubleMetaphone) Encode(word string) []string {
+	w := metaphoneWord(strings.ToUpper(strings.TrimSpace(word)))
+	result := &metaphoneResult{maxLength: d.maxLength}
+
+	if len(w) == 0 {
+		return []string{}
+	}
+
+	value := string(w)
+	slavoGermanic := strings.ContainsAny(value, "WK") || strings.Contains(value, "CZ") || strings.Contains(value, "WITZ")
+	index := 0
+
+	for _, start := range silentStarts {
+		if strings.HasPrefix(value, start) {
+			index = 1
+			break
+		}
+	}
+
+	for !result.isComplete() && index <= w.last() {
+		switch w[index] {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if index == 0 {
+				result.appendBoth("A")
+			}
+
+			index++
+		case 'B':
+			result.appendBoth("P")
+			index = skipDouble(w, index, 'B')
+		case 'Ç':
+			result.appendBoth("S")
+			index++
+		case 'C':
+			index = d.handleC(w, result, index)
+		case 'D':
+			index = d.handleD(w, result, index)
+		case 'F':
+			result.appendBoth("F")
+			index = skipDouble(w, index, 'F')
+		case 'G':
+			index = d.handleG(w, result, index, slavoGermanic)
+		case 'H':
+			index = d.handleH(w, result, index)
+		case 'J':
+			index = d.handleJ(w, result, index, slavoGermanic)
+		case 'K':
+			result.appendBoth("K")
+			index = skipDouble(w, index, 'K')
+		case 'L':
+			index = d.handleL(w, result, index)
+		case 'M':
+			result.appendBoth("M")
+
+			if d.conditionM0(w, index) {
+				index += 2
+			} else {
+				index++
+			}
+		case 'N':
+			result.appendBoth("N")
+			index = skipDouble(w, index, 'N')
+		case 'Ñ':
+			result.appendBoth("N")
+			index++
+		case 'P':
+			index = d.handleP(w, result, index)
+		case 'Q':
+			result.appendBoth("K")
+			index = skipDouble(w, index, 'Q')
+		case 'R':
+			index = d.handleR(w, result, index, slavoGermanic)
+		case 'S':
+			index = d.handleS(w, result, index, slavoGermanic)
+		case 'T':
+			index = d.handleT(w, result, index)
+		case 'V':
+			result.appendBoth("F")
+			index = skipDouble(w, index, 'V')
+		case 'W':
+			index = d.handleW(w, result, index)
+		case 'X':
+			index = d.handleX(w, result, index)
+		case 'Z':
+			index = d.handleZ(w, result, index, slavoGermanic)
+		default:
+			index++
+		}
+	}
+
+	return result.keys()
+}
+
+// skipDouble returns the position after the current character, the same next character is skipped too
+func skipDouble(w metaphoneWord, index int, c rune) int {
+	if w.at(index+1) == c {
+		return index + 2
+	}
+
+	return index + 1
+}
+
+func (d *doubleMetaphone) handleC(w metaphoneWord, result *metaphoneResult, index int) int {
+	switch {
+	case d.conditionC0(w, index):
+		result.appendBoth("K")
+		return index + 2
+	case index == 0 && w.contains(index, 6, "CAESAR"):
+		result.appendBoth("S")
+		return index + 2
+	case w.contains(index, 2, "CH"):
+		return d.handleCH(w, result, index)
+	case w.contains(index, 2, "CZ") && !w.contains(index-2, 4, "WICZ"):
+		result.append("S", "X")
+		return index + 2
+	case w.contains(index+1, 3, "CIA"):
+		result.appendBoth("X")
+		return index + 3
+	case w.contains(index, 2, "CC") && !(index == 1 && w.at(0) == 'M'):
+		return d.handleCC(w, result, index)
+	case w.contains(index, 2, "CK", "CG", "CQ"):
+		result.appendBoth("K")
+		return index + 2
+	case w.contains(index, 2, "CI", "CE", "CY"):
+		if w.contains(index, 3, "CIO", "CIE", "CIA") {
+			result.append("S", "X")
+		} else {
+			result.appendBoth("S")
+		}
+
+		return index + 2
+	}
+
+	result.appendBoth("K")
+
+	switch {
+	case w.contains(index+1, 2, " C", " Q", " G"):
+		return index + 3
+	case w.contains(index+1, 1, "C", "K", "Q") && !w.contains(index+1, 2, "CE", "CI"):
+		return index + 2
+	default:
+		return index + 1
+	}
+}
+
+func (d *doubleMetaphone) handleCC(w metaphoneWord, result *metaphoneResult, index int) int {
+	if w.contains(index+2, 1, "I", "E", "H") && !w.contains(index+2, 2, "HU") {
+		if (index == 1 && w.at(index-1) == 'A') || w.contains(index-1, 5, "UCCEE", "UCCES") {
+			result.appendBoth("KS")
+		} else {
+			result.appendBoth("X")
+		}
+
+		return index + 3
+	}
+
+	result.appendBoth("K")
+
+	return index + 2
+}
+
+func (d *doubleMetaphone) handleCH(w metaphoneWord, result *metaphoneResult, index int) int {
+	switch {
+	case index > 0 && w.contains(index, 4, "CHAE"):
+		result.append("K", "X")
+	case d.conditionCH0(w, index), d.conditionCH1(w, index):
+		result.appendBoth("K")
+	case index > 0:
+		if w.contains(0, 2, "MC") {
+			result.appendBoth("K")
+		} else {
+			result.append("X", "K")
+		}
+	default:
+		result.appendBoth("X")
+	}
+
+	return index + 2
+}
+
+func (d *doubleMetaphone) handleD(w metaphoneWord, result *metaphoneResult, index int) int {
+	switch {
+	case w.contains(index, 2, "DG"):
+		if w.contains(index+2, 1, "I", "E", "Y") {
+			result.appendBoth("J")
+			return index + 3
+		}
+
+		result.appendBoth("TK")
+
+		return index + 2
+	case w.contains(index, 2, "DT", "DD"):
+		result.appendBoth("T")
+		return index + 2
+	default:
+		result.appendBoth("T")
+		return index + 1
+	}
+}
+
+func (d *doubleMetaphone) handleG(w metaphoneWord, result *metaphoneResult, index int, slavoGermanic bool) int {
+	switch {
+	case w.at(index+1) == 'H':
+		return d.handleGH(w, result, index)
+	case w.at(index+1) == 'N':
+		switch {
+		case index == 1 && w.isVowel(0) && !slavoGermanic:
+			result.append("KN", "N")
+		case !w.contains(index+2, 2, "EY") && w.at(index+1) != 'Y' && !slavoGermanic:
+			result.append("N", "KN")
+		default:
+			result.appendBoth("KN")
+		}
+
+		return index + 2
+	case w.contains(index+1, 2, "LI") && !slavoGermanic:
+		result.append("KL", "L")
+		return index + 2
+	case index == 0 && (w.at(index+1) == 'Y' || w.contains(index+1, 2, esEpEbElEyIbIlEtc...)):
+		result.append("K", "J")
+		return index + 2
+	case (w.contains(index+1, 2, "ER") || w.at(index+1) == 'Y') &&
+		!w.contains(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!w.contains(index-1, 1, "E", "I") &&
+		!w.contains(index-1, 3, "RGY", "OGY"):
+		result.append("K", "J")
+		return index + 2
+	case w.contains(index+1, 1, "E", "I", "Y") || w.contains(index-1, 4, "AGGI", "OGGI"):
+		switch {
+		case w.contains(0, 4, "VAN ", "VON ") || w.contains(0, 3, "SCH") || w.contains(index+1, 2, "ET"):
+			result.appendBoth("K")
+		case w.contains(index+1, 3, "IER"):
+			result.appendBoth("J")
+		default:
+			result.append("J", "K")
+		}
+
+		return index + 2
+	case w.at(index+1) == 'G':
+		result.appendBoth("K")
+		return index + 2
+	default:
+		result.appendBoth("K")
+		return index + 1
+	}
+}
+
+func (d *doubleMetaphone) handleGH(w metaphoneWord, result *metaphoneResult, index int) int {
+	switch {
+	case index > 0 && !w.isVowel(index-1):
+		result.appendBoth("K")
+	case index == 0:
+		if w.at(index+2) == 'I' {
+			result.appendBoth("J")
+		} else {
+			result.appendBoth("K")
+		}
+	case (index > 1 && w.contains(index-2, 1, "B", "H", "D")) ||
+		(index > 2 && w.contains(index-3, 1, "B", "H", "D")) ||
+		(index > 3 && w.contains(index-4, 1, "B", "H")):
+		// silent, i.e. "hugh", "bough", "broughton"
+	case index > 2 && w.at(index-1) == 'U' && w.contains(index-3, 1, "C", "G", "L", "R", "T"):
+		result.appendBoth("F")
+	case index > 0 && w.at(index-1) != 'I':
+		result.appendBoth("K")
+	}
+
+	return index + 2
+}
+
+func (d *doubleMetaphone) handleH(w metaphoneWord, result *metaphoneResult, index int) int {
+	if (index == 0 || w.isVowel(index-1)) && w.isVowel(index+1) {
+		result.appendBoth("H")
+		return index + 2
+	}
+
+	return index + 1
+}
+
+func (d *doubleMetaphone) handleJ(w metaphoneWord, result *metaphoneResult, index int, slavoGermanic bool) int {
+	if w.contains(index, 4, "JOSE") || w.contains(0, 4, "SAN ") {
+		if (index == 0 && w.at(index+4) == ' ') || len(w) == 4 || w.contains(0, 4, "SAN ") {
+			result.appendBoth("H")
+		} else {
+			result.append("J", "H")
+		}
+
+		return index + 1
+	}
+
+	switch {
+	case index == 0:
+		result.append("J", "A")
+	case w.isVowel(index-1) && !slavoGermanic && (w.at(index+1) == 'A' || w.at(index+1) == 'O'):
+		result.append("J", "H")
+	case index == w.last():
+		result.appendPrimary("J")
+	case !w.contains(index+1, 1, ltksnmbz...) && !w.contains(index-1, 1, "S", "K", "L"):
+		result.appendBoth("J")
+	}
+
+	return skipDouble(w, index, 'J')
+}
+
+func (d *doubleMetaphone) handleL(w metaphoneWord, result *metaphoneResult, index int) int {
+	if w.at(index+1) != 'L' {
+		result.appendBoth("L")
+		return index + 1
+	}
+
+	if d.conditionL0(w, index) {
+		result.appendPrimary("L")
+	} else {
+		result.appendBoth("L")
+	}
+
+	return index + 2
+}
+
+func (d *doubleMetaphone) handleP(w metaphoneWord, result *metaphoneResult, index int) int {
+	if w.at(index+1) == 'H' {
+		result.appendBoth("F")
+		return index + 2
+	}
+
+	result.appendBoth("P")
+
+	if w.contains(index+1, 1, "P", "B") {
+		return index + 2
+	}
+
+	return index + 1
+}
+
+func (d *doubleMetaphone) handleR(w metaphoneWord, result *metaphoneResult, index int, slavoGermanic bool) int {
+	if index == w.last() && !slavoGermanic && w.contains(index-2, 2, "IE") && !w.contains(index-4, 2, "ME", "MA") {
+		result.appendAlternate("R")
+	} else {
+		result.appendBoth("R")
+	}
+
+	return skipDouble(w, index, 'R')
+}
+
+func (d *doubleMetaphone) handleS(w metaphoneWord, result *metaphoneResult, index int, slavoGermanic bool) int {
+	switch {
+	case w.contains(index-1, 3, "ISL", "YSL"):
+		return index + 1
+	case index == 0 && w.contains(index, 5, "SUGAR"):
+		result.append("X", "S")
+		return index + 1
+	case w.contains(index, 2, "SH"):
+		if w.contains(index+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			result.appendBoth("S")
+		} else {
+			result.appendBoth("X")
+		}
+
+		return index + 2
+	case w.contains(index, 3, "SIO", "SIA") || w.contains(index, 4, "SIAN"):
+		if slavoGermanic {
+			result.appendBoth("S")
+		} else {
+			result.append("S", "X")
+		}
+
+		return index + 3
+	case (index == 0 && w.contains(index+1, 1, "M", "N", "L", "W")) || w.contains(index+1, 1, "Z"):
+		result.append("S", "X")
+
+		if w.contains(index+1, 1, "Z") {
+			return index + 2
+		}
+
+		return index + 1
+	case w.contains(index, 2, "SC"):
+		return d.handleSC(w, result, index)
+	}
+
+	if index == w.last() && w.contains(index-2, 2, "AI", "OI") {
+		result.appendAlternate("S")
+	} else {
+		result.appendBoth("S")
+	}
+
+	if w.contains(index+1, 1, "S", "Z") {
+		return index + 2
+	}
+
+	return index + 1
+}
+
+func (d *doubleMetaphone) handleSC(w metaphoneWord, result *metaphoneResult, index int) int {
+	switch {
+	case w.at(index+2) == 'H':
+		switch {
+		case w.contains(index+3, 2, "ER", "EN"):
+			result.append("X", "SK")
+		case w.contains(index+3, 2, "OO", "UY", "ED", "EM"):
+			result.appendBoth("SK")
+		case index == 0 && !w.isVowel(3) && w.at(3) != 'W':
+			result.append("X", "S")
+		default:
+			result.appendBoth("X")
+		}
+	case w.contains(index+2, 1, "I", "E", "Y"):
+		result.appendBoth("S")
+	default:
+		result.appendBoth("SK")
+	}
+
+	return index + 3
+}
+
+func (d *doubleMetaphone) handleT(w metaphoneWord, result *metaphoneResult, index int) int {
+	switch {
+	case w.contains(index, 4, "TION"), w.contains(index, 3, "TIA", "TCH"):
+		result.appendBoth("X")
+		return index + 3
+	case w.contains(index, 2, "TH") || w.contains(index, 3, "TTH"):
+		if w.contains(index+2, 2, "OM", "AM") || w.contains(0, 4, "VAN ", "VON ") || w.contains(0, 3, "SCH") {
+			result.appendBoth("T")
+		} else {
+			result.append("0", "T")
+		}
+
+		return index + 2
+	}
+
+	result.appendBoth("T")
+
+	if w.contains(index+1, 1, "T", "D") {
+		return index + 2
+	}
+
+	return index + 1
+}
+
+func (d *doubleMetaphone) handleW(w metaphoneWord, result *metaphoneResult, index int) int {
+	switch {
+	case w.contains(index, 2, "WR"):
+		result.appendBoth("R")
+		return index + 2
+	case index == 0 && (w.isVowel(index+1) || w.contains(index, 2, "WH")):
+		if w.isVowel(index + 1) {
+			result.append("A", "F")
+		} else {
+			result.appendBoth("A")
+		}
+	case (index == w.last() && w.isVowel(index-1)) ||
+		w.contains(index-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
+		w.contains(0, 3, "SCH"):
+		result.appendAlternate("F")
+	case w.contains(index, 4, "WICZ", "WITZ"):
+		result.append("TS", "FX")
+		return index + 4
+	}
+
+	return index + 1
+}
+
+func (d *doubleMetaphone) handleX(w metaphoneWord, result *metaphoneResult, index int) int {
+	if index == 0 {
+		result.appendBoth("S")
+		return index + 1
+	}
+
+	if !(index == w.last() && (w.contains(index-3, 3, "IAU", "EAU") || w.contains(index-2, 2, "AU", "OU"))) {
+		result.appendBoth("KS")
+	}
+
+	if w.contains(index+1, 1, "C", "X") {
+		return index + 2
+	}
+
+	return index + 1
+}
+
+func (d *doubleMetaphone) handleZ(w metaphoneWord, result *metaphoneResult, index int, slavoGermanic bool) int {
+	if w.at(index+1) == 'H' {
+		result.appendBoth("J")
+		return index + 2
+	}
+
+	if w.contains(index+1, 2, "ZO", "ZI", "ZA") || (slavoGermanic && index > 0 && w.at(index-1) != 'T') {
+		result.append("S", "TS")
+	} else {
+		result.appendBoth("S")
+	}
+
+	return skipDouble(w, index, 'Z')
+}
+
+// conditionC0 tells about the various germanic "-ACH-"
+func (d *doubleMetaphone) conditionC0(w metaphoneWord, index int) bool {
+	switch {
+	case w.contains(index, 4, "CHIA"):
+		return true
+	case index <= 1, w.isVowel(index - 2), !w.contains(index-1, 3, "ACH"):
+		return false
+	}
+
+	c := w.at(index + 2)
+
+	return (c != 'I' && c != 'E') || w.contains(index-2, 6, "BACHER", "MACHER")
+}
+
+// conditionCH0 tells about the greek roots, i.e. "chemistry", "chorus"
+func (d *doubleMetaphone) conditionCH0(w metaphoneWord, index int) bool {
+	if index != 0 {
+		return false
+	}
+
+	if !w.contains(index+1, 5, "HARAC", "HARIS") && !w.contains(index+1, 3, "HOR", "HYM", "HIA", "HEM") {
+		return false
+	}
+
+	return !w.contains(0, 5, "CHORE")
+}
+
+// conditionCH1 tells about the germanic, the greek and the other words, where "CH" sounds as "K"
+func (d *doubleMetaphone) conditionCH1(w metaphoneWord, index int) bool {
+	return w.contains(0, 4, "VAN ", "VON ") || w.contains(0, 3, "SCH") ||
+		w.contains(index-2, 6, "ORCHES", "ARCHIT", "ORCHID") ||
+		w.contains(index+2, 1, "T", "S") ||
+		((w.contains(index-1, 1, "A", "O", "U", "E") || index == 0) &&
+			(w.contains(index+2, 1, lrnmbhfvwSpace...) || index+1 == w.last()))
+}
+
+// conditionL0 tells about the spanish words, i.e. "cabrillo", "gallegos"
+func (d *doubleMetaphone) conditionL0(w metaphoneWord, index int) bool {
+	if index == len(w)-3 && w.contains(index-1, 4, "ILLO", "ILLA", "ALLE") {
+		return true
+	}
+
+	return (w.contains(len(w)-2, 2, "AS", "OS") || w.contains(len(w)-1, 1, "A", "O")) &&
+		w.contains(index-1, 4, "ALLE")
+}
+
+// conditionM0 tells whether the next character is silent, i.e. "dumb", "thumb"
+func (d *doubleMetaphone) conditionM0(w metaphoneWord, index int) bool {
+	if w.at(index+1) == 'M' {
+		return true
+	}
+
+	return w.contains(index-1, 3, "UMB") && (index+1 == w.last() || w.contains(index+2, 2, "ER"))
+}
@@ -0,0 +1,97 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyboardLayout maps the characters of one keyboard layout to the characters,
+// that are typed by the same keys in another layout
+type KeyboardLayout map[rune]rune
+
+const (
+	qwertyKeys = "`qwertyuiop[]asdfghjkl;'zxcvbnm,./" + "~QWERTYUIOP{}ASDFGHJKL:\"ZXCVBNM<>?"
+	jcukenKeys = "ёйцукенгшщзхъфывапролджэячсмитьбю." + "ЁЙЦУКЕНГШЩЗХЪФЫВАПРОЛДЖЭЯЧСМИТЬБЮ,"
+)
+
+var (
+	// QwertyToJcukenLayout maps a text typed with QWERTY layout to the text,
+	// that would be typed by the same keys with ЙЦУКЕН layout, i.e. "vfplf" -> "мазда"
+	QwertyToJcukenLayout = mustKeyboardLayout(qwertyKeys, jcukenKeys)
+	// JcukenToQwertyLayout maps a text typed with ЙЦУКЕН layout to the text,
+	// that would be typed by the same keys with QWERTY layout, i.e. "иьц" -> "bmw"
+	JcukenToQwertyLayout = mustKeyboardLayout(jcukenKeys, qwertyKeys)
+)
+
+// NewKeyboardLayout creates a new keyboard layout, that maps each character of from
+// to the character of to with the same position
+func NewKeyboardLayout(from, to string) (KeyboardLayout, error) {
+	source, target := []rune(from), []rune(to)
+
+	if len(source) != len(target) {
+		return nil, fmt.Errorf("keyboard layouts should have the same number of keys, got %d and %d", len(source), len(target))
+	}
+
+	layout := make(KeyboardLayout, len(source))
+
+	for i, r := range source {
+		layout[r] = target[i]
+	}
+
+	return layout, nil
+}
+
+// GetKeyboardLayout returns the predefined keyboard layout with the given name
+func GetKeyboardLayout(name string) (KeyboardLayout, error) {
+	switch name {
+	case "qwerty-jcuken":
+		return QwertyToJcukenLayout, nil
+	case "jcuken-qwerty":
+		return JcukenToQwertyLayout, nil
+	default:
+		return nil, fmt.Errorf("keyboard layout %s is not supported", name)
+	}
+}
+
+// Remap returns the text typed by the same keys with the target layout,
+// the characters that are absent in the layout are kept as is
+func (l KeyboardLayout) Remap(text string) string {
+	return strings.Map(func(r rune) rune {
+		if mapped, ok := l[r]; ok {
+			return mapped
+		}
+
+		return r
+	}, text)
+}
+
+// mustKeyboardLayout creates a new keyboard layout and panics on an error
+func mustKeyboardLayout(from, to string) KeyboardLayout {
+	layout, err := NewKeyboardLayout(from, to)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return layout
+}
+
+type keyboardLayoutFilter struct {
+	layout KeyboardLayout
+}
+
+// NewKeyboardLayoutFilter returns a tokens filter, that remaps each token through the given keyboard layout
+func NewKeyboardLayoutFilter(layout KeyboardLayout) TokenFilter {
+	return &keyboardLayoutFilter{
+		layout: layout,
+	}
+}
+
+// Filter filters the given list with described behaviour
+func (f *keyboardLayoutFilter) Filter(list []Token) []Token {
+	for i, token := range list {
+		list[i] = f.layout.Remap(token)
+	}
+
+	return list
+}
@@ -0,0 +1,33 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyboardLayout(t *testing.T) {
+	testCases := []struct {
+		layout   KeyboardLayout
+		text     string
+		expected string
+	}{
+		{JcukenToQwertyLayout, "иьц ч5", "bmw x5"},
+		{JcukenToQwertyLayout, "ИЬЦ", "BMW"},
+		{QwertyToJcukenLayout, "vfplf 6", "мазда 6"},
+		{QwertyToJcukenLayout, "`krf", "ёлка"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, testCase.layout.Remap(testCase.text))
+	}
+
+	filter := NewKeyboardLayoutFilter(JcukenToQwertyLayout)
+	assert.Equal(t, []Token{"bmw", "x5"}, filter.Filter([]Token{"иьц", "ч5"}))
+
+	_, err := NewKeyboardLayout("abc", "аб")
+	assert.Error(t, err)
+
+	_, err = GetKeyboardLayout("dvorak")
+	assert.Error(t, err)
+}
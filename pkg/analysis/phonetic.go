@@ -0,0 +1,190 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// PhoneticEncoder encodes a word into the keys of its pronunciation, so the words,
+// that sound alike, i.e. "Smith" and "Smyth", share a key
+type PhoneticEncoder interface {
+	// Encode returns the keys of the given word, or an empty list if the word
+	// has no letters of the encoder language
+	Encode(word string) []string
+}
+
+// GetPhoneticEncoder returns the phonetic encoder with the given name
+func GetPhoneticEncoder(name string) (PhoneticEncoder, error) {
+	switch name {
+	case "doubleMetaphone":
+		return NewDoubleMetaphoneEncoder(DefaultMetaphoneLength), nil
+	case "soundex":
+		return NewSoundexEncoder(), nil
+	case "russianMetaphone":
+		return NewRussianMetaphoneEncoder(), nil
+	default:
+		return nil, fmt.Errorf("phonetic encoder %s is not supported", name)
+	}
+}
+
+type phoneticFilter struct {
+	encoder PhoneticEncoder
+}
+
+// NewPhoneticFilter returns a tokens filter, that replaces each token with its phonetic keys.
+// The tokens, that can't be encoded, are dropped
+func NewPhoneticFilter(encoder PhoneticEncoder) TokenFilter {
+	return &phoneticFilter{
+		encoder: encoder,
+	}
+}
+
+// Filter filters the given list with described behaviour
+func (f *phoneticFilter) Filter(list []Token) []Token {
+	keys := make([]Token, 0, len(list))
+
+	for _, token := range list {
+		keys = append(keys, f.encoder.Encode(token)...)
+	}
+
+	return keys
+}
+
+// soundexCodes maps the consonants of English to the digits of American Soundex
+var soundexCodes = map[rune]byte{
+	'B': '1', 'F': '1', 'P': '1', 'V': '1',
+	'C': '2', 'G': '2', 'J': '2', 'K': '2', 'Q': '2', 'S': '2', 'X': '2', 'Z': '2',
+	'D': '3', 'T': '3',
+	'L': '4',
+	'M': '5', 'N': '5',
+	'R': '6',
+}
+
+type soundex struct{}
+
+// NewSoundexEncoder returns American Soundex encoder, that encodes an English word
+// into its first letter followed by three digits, i.e. "Robert" -> "R163"
+func NewSoundexEncoder() PhoneticEncoder {
+	return &soundex{}
+}
+
+// Encode returns the Soundex key of the given word
+func (s *soundex) Encode(word string) []string {
+	key := make([]byte, 0, 4)
+	var last byte
+
+	for _, r := range strings.ToUpper(word) {
+		if r < 'A' || r > 'Z' {
+			continue
+		}
+
+		code := soundexCodes[r]
+
+		if len(key) == 0 {
+			key = append(key, byte(r))
+			last = code
+
+			continue
+		}
+
+		switch {
+		case r == 'H' || r == 'W':
+			// H and W don't separate the consonants of the same code
+		case code == 0:
+			last = 0
+		case code != last:
+			key = append(key, code)
+			last = code
+		}
+
+		if len(key) == 4 {
+			break
+		}
+	}
+
+	if len(key) == 0 {
+		return []string{}
+	}
+
+	for len(key) < 4 {
+		key = append(key, '0')
+	}
+
+	return []string{string(key)}
+}
+
+var (
+	// russianVowels maps the vowels of Russian to the vowels of their sound in an unstressed syllable
+	russianVowels = map[rune]rune{
+		'А': 'А', 'О': 'А', 'Ы': 'А', 'Я': 'А',
+		'Е': 'И', 'Ё': 'И', 'Э': 'И', 'И': 'И',
+		'У': 'У', 'Ю': 'У',
+	}
+	// russianDevoicing maps the voiced consonants of Russian to their voiceless pairs
+	russianDevoicing = map[rune]rune{
+		'Б': 'П', 'В': 'Ф', 'Г': 'К', 'Д': 'Т', 'Ж': 'Ш', 'З': 'С',
+	}
+	// russianVoiceless is the set of the voiceless consonants of Russian
+	russianVoiceless = "ПФКТШСХЦЧЩ"
+)
+
+type russianMetaphone struct{}
+
+// NewRussianMetaphoneEncoder returns the encoder of Russian words, that follows Metaphone adapted
+// to Russian by Petrov: the unstressed vowels are reduced, the consonants are devoiced before
+// a voiceless consonant and at the end of the word, the soft and hard signs are dropped
+// and the repeated sounds are collapsed, i.e. "Сергеев" and "Сиргеев" share "СИРГИФ"
+func NewRussianMetaphoneEncoder() PhoneticEncoder {
+	return &russianMetaphone{}
+}
+
+// Encode returns the Russian metaphone key of the given word
+func (m *russianMetaphone) Encode(word string) []string {
+	letters := make([]rune, 0, len(word))
+
+	for _, r := range word {
+		if r = unicode.ToUpper(r); ((r >= 'А' && r <= 'Я') || r == 'Ё') && r != 'Ь' && r != 'Ъ' {
+			letters = append(letters, r)
+		}
+	}
+
+	if len(letters) == 0 {
+		return []string{}
+	}
+
+	key := make([]rune, 0, len(letters))
+	next := func(i int) rune {
+		if i+1 < len(letters) {
+			return letters[i+1]
+		}
+
+		return 0
+	}
+
+	for i := 0; i < len(letters); i++ {
+		r := letters[i]
+		var sound rune
+
+		switch {
+		case (r == 'Й' || r == 'И') && (next(i) == 'О' || next(i) == 'Е'):
+			sound = 'И'
+			i++
+		case (r == 'Т' || r == 'Д') && next(i) == 'С':
+			sound = 'Ц'
+			i++
+		case russianVowels[r] != 0:
+			sound = russianVowels[r]
+		case russianDevoicing[r] != 0 && (next(i) == 0 || strings.ContainsRune(russianVoiceless, next(i))):
+			sound = russianDevoicing[r]
+		default:
+			sound = r
+		}
+
+		if len(key) == 0 || key[len(key)-1] != sound {
+			key = append(key, sound)
+		}
+	}
+
+	return []string{string(key)}
+}
@@ -0,0 +1,86 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoubleMetaphone(t *testing.T) {
+	testCases := []struct {
+		word     string
+		expected []string
+	}{
+		{"Smith", []string{"SM0", "XMT"}},
+		{"Schmidt", []string{"XMT", "SMT"}},
+		{"photograph", []string{"FTKR"}},
+		{"fotograf", []string{"FTKR"}},
+		{"thumb", []string{"0M", "TM"}},
+		{"knight", []string{"NT"}},
+		{"Jose", []string{"HS"}},
+		{"Caesar", []string{"SSR"}},
+		{"chemistry", []string{"KMST"}},
+		{"Xavier", []string{"SF", "SFR"}},
+		{"", []string{}},
+		{"мерседес", []string{}},
+	}
+
+	encoder := NewDoubleMetaphoneEncoder(DefaultMetaphoneLength)
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, encoder.Encode(testCase.word), testCase.word)
+	}
+}
+
+func TestSoundex(t *testing.T) {
+	testCases := []struct {
+		word     string
+		expected []string
+	}{
+		{"Robert", []string{"R163"}},
+		{"Rupert", []string{"R163"}},
+		{"Ashcraft", []string{"A261"}},
+		{"Tymczak", []string{"T522"}},
+		{"Pfister", []string{"P236"}},
+		{"Lee", []string{"L000"}},
+		{"123", []string{}},
+	}
+
+	encoder := NewSoundexEncoder()
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, encoder.Encode(testCase.word), testCase.word)
+	}
+}
+
+func TestRussianMetaphone(t *testing.T) {
+	testCases := []struct {
+		word     string
+		expected []string
+	}{
+		{"Сергеев", []string{"СИРГИФ"}},
+		{"Сиргеев", []string{"СИРГИФ"}},
+		{"лодка", []string{"ЛАТКА"}},
+		{"лотка", []string{"ЛАТКА"}},
+		{"ёжик", []string{"ИЖИК"}},
+		{"детство", []string{"ДИЦТВА"}},
+		{"Mercedes", []string{}},
+	}
+
+	encoder := NewRussianMetaphoneEncoder()
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, encoder.Encode(testCase.word), testCase.word)
+	}
+}
+
+func TestPhoneticFilter(t *testing.T) {
+	encoder, err := GetPhoneticEncoder("russianMetaphone")
+	assert.NoError(t, err)
+
+	filter := NewPhoneticFilter(encoder)
+	assert.Equal(t, []Token{"ЛАТКА", "ИЖИК"}, filter.Filter([]Token{"лодка", "bmw", "ежик"}))
+
+	_, err = GetPhoneticEncoder("caverphone")
+	assert.Error(t, err)
+}
@@ -0,0 +1,52 @@
+package analysis
+
+// Stage is a step of an analysis pipeline, that transforms a token flow
+type Stage func(list []Token) []Token
+
+// TokenizerStage returns a pipeline stage, that splits each token of the flow with the given tokenizer
+func TokenizerStage(tokenizer Tokenizer) Stage {
+	return func(list []Token) []Token {
+		tokens := []Token{}
+
+		for _, token := range list {
+			for _, t := range tokenizer.Tokenize(token) {
+				tokens = appendUnique(tokens, t)
+			}
+		}
+
+		return tokens
+	}
+}
+
+// FilterStage returns a pipeline stage, that filters the token flow with the given filter
+func FilterStage(filter TokenFilter) Stage {
+	return filter.Filter
+}
+
+// pipelineTokenizer passes a text through the stages and then splits each resulting token
+type pipelineTokenizer struct {
+	stages    []Stage
+	tokenizer Tokenizer
+}
+
+// NewPipelineTokenizer creates a new instance of tokenizer, that passes the text as a single token
+// through the given stages and then splits each resulting token with the tokenizer.
+// The filters before the first tokenizer stage transform the whole text, i.e. transliteration,
+// the filters after it transform the separate tokens, i.e. stemming of words
+func NewPipelineTokenizer(stages []Stage, tokenizer Tokenizer) Tokenizer {
+	return &pipelineTokenizer{
+		stages:    stages,
+		tokenizer: tokenizer,
+	}
+}
+
+// Tokenize splits the given text on a sequence of tokens
+func (t *pipelineTokenizer) Tokenize(text string) []Token {
+	list := []Token{text}
+
+	for _, stage := range t.stages {
+		list = stage(list)
+	}
+
+	return TokenizerStage(t.tokenizer)(list)
+}
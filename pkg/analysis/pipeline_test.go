@@ -0,0 +1,73 @@
+package analysis
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipelineTokenizer(t *testing.T) {
+	definitions := []Definition{}
+	config := `[
+		{"type": "lowercase"},
+		{"type": "word", "alphabet": ["english"]},
+		{"type": "stopWords", "lang": "en"},
+		{"type": "stemmer", "lang": "en"}
+	]`
+
+	assert.NoError(t, json.Unmarshal([]byte(config), &definitions))
+
+	stages, err := NewStages(definitions)
+	assert.NoError(t, err)
+
+	tokenizer := NewPipelineTokenizer(stages, NewNGramTokenizer(3))
+
+	assert.Equal(t, []Token{"run", "fox", "jum", "ump"}, tokenizer.Tokenize("The Running Fox jumps"))
+}
+
+func TestTextPipelineTokenizer(t *testing.T) {
+	definition, err := NewDefinition("transliteration", map[string]string{"scheme": "informal"})
+	assert.NoError(t, err)
+
+	stages, err := NewStages([]Definition{definition})
+	assert.NoError(t, err)
+
+	tokenizer := NewPipelineTokenizer(stages, NewNGramTokenizer(3))
+
+	assert.Equal(t, []Token{"zhu", "huk"}, tokenizer.Tokenize("жук"))
+	assert.Equal(t, NewNGramTokenizer(3).Tokenize("mersedes"), tokenizer.Tokenize("мерседес"))
+}
+
+func TestRegistry(t *testing.T) {
+	RegisterFilter("reverse", func(definition Definition) (TokenFilter, error) {
+		return NewLowercaseFilter(), nil
+	})
+
+	assert.Panics(t, func() {
+		RegisterTokenizer("reverse", func(definition Definition) (Tokenizer, error) {
+			return NewNGramTokenizer(3), nil
+		})
+	})
+
+	for _, config := range []string{
+		`{"type": "reverse"}`,
+		`{"type": "unknown"}`,
+		`{"type": "stemmer", "lang": "de"}`,
+		`{"type": "ngram", "size": 0}`,
+		`{"type": "transliteration", "scheme": "bgn"}`,
+	} {
+		definition := Definition{}
+		assert.NoError(t, json.Unmarshal([]byte(config), &definition))
+
+		_, err := NewStage(definition)
+
+		if definition.Type == "reverse" {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+		}
+	}
+
+	assert.Error(t, json.Unmarshal([]byte(`{"lang": "en"}`), &Definition{}))
+}
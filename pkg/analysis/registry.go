@@ -0,0 +1,159 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Definition is a declaration of an analysis pipeline stage, i.e. {"type": "stemmer", "lang": "en"}.
+// Type is a name of a registered tokenizer or token filter, the rest fields are its parameters
+type Definition struct {
+	Type   string
+	params json.RawMessage
+}
+
+// NewDefinition creates a new definition of the stage of the given type with the given parameters
+func NewDefinition(kind string, params interface{}) (Definition, error) {
+	data, err := json.Marshal(params)
+
+	if err != nil {
+		return Definition{}, fmt.Errorf("failed to marshal %s parameters: %w", kind, err)
+	}
+
+	return Definition{Type: kind, params: data}, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler interface
+func (d *Definition) UnmarshalJSON(data []byte) error {
+	header := struct {
+		Type string `json:"type"`
+	}{}
+
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	if header.Type == "" {
+		return fmt.Errorf("analysis stage should have a type")
+	}
+
+	d.Type = header.Type
+	d.params = append(json.RawMessage(nil), data...)
+
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler interface
+func (d Definition) MarshalJSON() ([]byte, error) {
+	params := map[string]interface{}{}
+
+	if err := d.Decode(&params); err != nil {
+		return nil, err
+	}
+
+	params["type"] = d.Type
+
+	return json.Marshal(params)
+}
+
+// Decode stores the parameters of the definition in the value pointed to by v
+func (d Definition) Decode(v interface{}) error {
+	if len(d.params) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(d.params, v); err != nil {
+		return fmt.Errorf("failed to decode %s parameters: %w", d.Type, err)
+	}
+
+	return nil
+}
+
+// TokenizerFactory creates a tokenizer from its definition
+type TokenizerFactory func(definition Definition) (Tokenizer, error)
+
+// FilterFactory creates a token filter from its definition
+type FilterFactory func(definition Definition) (TokenFilter, error)
+
+var (
+	registryLock sync.RWMutex
+	tokenizers   = map[string]TokenizerFactory{}
+	filters      = map[string]FilterFactory{}
+)
+
+// RegisterTokenizer makes a tokenizer available by the provided type in analysis pipelines.
+// If RegisterTokenizer is called twice with the same type, it panics
+func RegisterTokenizer(kind string, factory TokenizerFactory) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	checkNotRegistered(kind)
+	tokenizers[kind] = factory
+}
+
+// RegisterFilter makes a token filter available by the provided type in analysis pipelines.
+// If RegisterFilter is called twice with the same type, it panics
+func RegisterFilter(kind string, factory FilterFactory) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	checkNotRegistered(kind)
+	filters[kind] = factory
+}
+
+// NewStage creates a pipeline stage from the given definition with the registered factory
+func NewStage(definition Definition) (Stage, error) {
+	registryLock.RLock()
+	tokenizerFactory, isTokenizer := tokenizers[definition.Type]
+	filterFactory, isFilter := filters[definition.Type]
+	registryLock.RUnlock()
+
+	switch {
+	case isTokenizer:
+		tokenizer, err := tokenizerFactory(definition)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s tokenizer: %w", definition.Type, err)
+		}
+
+		return TokenizerStage(tokenizer), nil
+	case isFilter:
+		filter, err := filterFactory(definition)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s filter: %w", definition.Type, err)
+		}
+
+		return FilterStage(filter), nil
+	default:
+		return nil, fmt.Errorf("analysis stage %s is not registered", definition.Type)
+	}
+}
+
+// NewStages creates the pipeline stages from the given definitions
+func NewStages(definitions []Definition) ([]Stage, error) {
+	stages := make([]Stage, 0, len(definitions))
+
+	for _, definition := range definitions {
+		stage, err := NewStage(definition)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return stages, nil
+}
+
+// checkNotRegistered panics if a tokenizer or a filter with the given type is already registered
+func checkNotRegistered(kind string) {
+	_, isTokenizer := tokenizers[kind]
+	_, isFilter := filters[kind]
+
+	if isTokenizer || isFilter {
+		panic(fmt.Sprintf("analysis stage %s is already registered", kind))
+	}
+}
@@ -48,6 +48,30 @@ func (f *stemmerFilter) Filter(list []Token) []Token {
 	return filtered
 }
 
+type stopWordsFilter struct {
+	stopWords stopWordsSet
+}
+
+// NewStopWordsFilter creates a new filter, that removes the given words from the token flow
+func NewStopWordsFilter(words []string) TokenFilter {
+	return &stopWordsFilter{
+		stopWords: stopWordsToSet(words),
+	}
+}
+
+// Filter filters the given list with described behaviour
+func (f *stopWordsFilter) Filter(list []Token) []Token {
+	filtered := []Token{}
+
+	for _, token := range list {
+		if _, ok := f.stopWords[token]; !ok {
+			filtered = append(filtered, token)
+		}
+	}
+
+	return filtered
+}
+
 func stopWordsToSet(list []string) stopWordsSet {
 	set := make(stopWordsSet, len(list))
 
@@ -0,0 +1,113 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Transliteration is a scheme, that maps each Cyrillic letter to its Latin spelling.
+// The characters absent in the scheme, i.e. Latin letters and digits, are kept as is,
+// so a Cyrillic text and its Latin spelling are transliterated to the same text
+type Transliteration map[rune]string
+
+var (
+	// GOSTTransliteration is the scheme of GOST R 52535.1-2006, that is used in Russian passports
+	GOSTTransliteration = newTransliteration(
+		"абвгдеёжзийклмнопрстуфхцчшщъыьэюя",
+		"a", "b", "v", "g", "d", "e", "e", "zh", "z", "i", "i", "k", "l", "m", "n", "o", "p",
+		"r", "s", "t", "u", "f", "kh", "tc", "ch", "sh", "shch", "ie", "y", "", "e", "iu", "ia",
+	)
+	// ISO9Transliteration is the scheme of ISO 9:1995, that maps each letter to a single
+	// Latin letter with diacritics, so it can be reversed
+	ISO9Transliteration = newTransliteration(
+		"абвгдеёжзийклмнопрстуфхцчшщъыьэюя",
+		"a", "b", "v", "g", "d", "e", "ë", "ž", "z", "i", "j", "k", "l", "m", "n", "o", "p",
+		"r", "s", "t", "u", "f", "h", "c", "č", "š", "ŝ", "ʺ", "y", "ʹ", "è", "û", "â",
+	)
+	// InformalTransliteration is the scheme, that people usually follow when they
+	// type a Russian word with Latin letters, i.e. "мерседес" -> "mersedes"
+	InformalTransliteration = newTransliteration(
+		"абвгдеёжзийклмнопрстуфхцчшщъыьэюя",
+		"a", "b", "v", "g", "d", "e", "e", "zh", "z", "i", "y", "k", "l", "m", "n", "o", "p",
+		"r", "s", "t", "u", "f", "h", "ts", "ch", "sh", "sch", "", "y", "", "e", "yu", "ya",
+	)
+)
+
+// GetTransliteration returns the predefined transliteration scheme with the given name
+func GetTransliteration(name string) (Transliteration, error) {
+	switch name {
+	case "gost":
+		return GOSTTransliteration, nil
+	case "iso9":
+		return ISO9Transliteration, nil
+	case "informal":
+		return InformalTransliteration, nil
+	default:
+		return nil, fmt.Errorf("transliteration %s is not supported", name)
+	}
+}
+
+// Transliterate returns the Latin spelling of the given text
+func (t Transliteration) Transliterate(text string) string {
+	builder := strings.Builder{}
+	builder.Grow(len(text))
+
+	for _, r := range text {
+		spelling, ok := t[unicode.ToLower(r)]
+
+		if !ok {
+			builder.WriteRune(r)
+			continue
+		}
+
+		if unicode.IsUpper(r) && spelling != "" {
+			first, size := utf8.DecodeRuneInString(spelling)
+			builder.WriteRune(unicode.ToUpper(first))
+			spelling = spelling[size:]
+		}
+
+		builder.WriteString(spelling)
+	}
+
+	return builder.String()
+}
+
+// newTransliteration creates a new transliteration scheme, that maps
+// each letter of the alphabet to the spelling with the same position
+func newTransliteration(alphabet string, spellings ...string) Transliteration {
+	letters := []rune(alphabet)
+
+	if len(letters) != len(spellings) {
+		panic(fmt.Sprintf("transliteration should have a spelling for each letter, got %d and %d", len(letters), len(spellings)))
+	}
+
+	transliteration := make(Transliteration, len(letters))
+
+	for i, r := range letters {
+		transliteration[r] = spellings[i]
+	}
+
+	return transliteration
+}
+
+type transliterationFilter struct {
+	transliteration Transliteration
+}
+
+// NewTransliterationFilter returns a tokens filter, that replaces each token with its Latin spelling
+func NewTransliterationFilter(transliteration Transliteration) TokenFilter {
+	return &transliterationFilter{
+		transliteration: transliteration,
+	}
+}
+
+// Filter filters the given list with described behaviour
+func (f *transliterationFilter) Filter(list []Token) []Token {
+	for i, token := range list {
+		list[i] = f.transliteration.Transliterate(token)
+	}
+
+	return list
+}
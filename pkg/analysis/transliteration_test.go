@@ -0,0 +1,29 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransliteration(t *testing.T) {
+	testCases := []struct {
+		transliteration Transliteration
+		text            string
+		expected        string
+	}{
+		{InformalTransliteration, "мерседес", "mersedes"},
+		{InformalTransliteration, "Mercedes", "Mercedes"},
+		{InformalTransliteration, "Щука и ёж", "Schuka i ezh"},
+		{GOSTTransliteration, "Хабаровск", "Khabarovsk"},
+		{GOSTTransliteration, "объявление", "obieiavlenie"},
+		{ISO9Transliteration, "щука", "ŝuka"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, testCase.transliteration.Transliterate(testCase.text))
+	}
+
+	_, err := GetTransliteration("bgn")
+	assert.Error(t, err)
+}
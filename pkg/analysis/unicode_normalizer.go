@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// UnicodeNormalization describes how a text is brought to the canonical form, so the differently
+// written forms of a word, i.e. "Škoda" and "skoda", share the same n-grams.
+// NFKC normalization is always performed, the rest steps are optional
+type UnicodeNormalization struct {
+	// CaseFolding performs full Unicode case folding, i.e. "Straße" -> "strasse"
+	CaseFolding bool `json:"caseFolding"`
+	// StripDiacritics removes the combining marks of the characters, i.e. "Citroën" -> "Citroen"
+	StripDiacritics bool `json:"stripDiacritics"`
+	// Mappings replaces the characters with the given strings, i.e. "ё" -> "е". The mapped
+	// characters are not stripped of diacritics, so a mapping of "й" to itself keeps "й" as is
+	Mappings map[string]string `json:"mappings"`
+}
+
+// Validate checks that each mapping replaces a single character
+func (n UnicodeNormalization) Validate() error {
+	for from := range n.Mappings {
+		if utf8.RuneCountInString(norm.NFKC.String(from)) != 1 {
+			return fmt.Errorf("normalization mapping should replace a single character, got %q", from)
+		}
+	}
+
+	return nil
+}
+
+type unicodeNormalizer struct {
+	caseFolding     bool
+	stripDiacritics bool
+	mappings        map[rune]string
+}
+
+// NewUnicodeNormalizerFilter returns a tokens filter, that normalizes each token with the given normalization
+func NewUnicodeNormalizerFilter(normalization UnicodeNormalization) (TokenFilter, error) {
+	if err := normalization.Validate(); err != nil {
+		return nil, err
+	}
+
+	mappings := make(map[rune]string, len(normalization.Mappings))
+
+	for from, to := range normalization.Mappings {
+		from = norm.NFKC.String(from)
+
+		if normalization.CaseFolding {
+			from = cases.Fold().String(from)
+		}
+
+		r, _ := utf8.DecodeRuneInString(from)
+		mappings[r] = to
+	}
+
+	return &unicodeNormalizer{
+		caseFolding:     normalization.CaseFolding,
+		stripDiacritics: normalization.StripDiacritics,
+		mappings:        mappings,
+	}, nil
+}
+
+// Filter filters the given list with described behaviour
+func (f *unicodeNormalizer) Filter(list []Token) []Token {
+	// cases.Caser keeps a state, so it can't be shared between goroutines
+	folder := cases.Fold()
+
+	for i, token := range list {
+		list[i] = f.normalize(token, folder)
+	}
+
+	return list
+}
+
+// normalize returns the canonical form of the given token
+func (f *unicodeNormalizer) normalize(token Token, folder cases.Caser) Token {
+	token = norm.NFKC.String(token)
+
+	if f.caseFolding {
+		token = folder.String(token)
+	}
+
+	if len(f.mappings) == 0 && !f.stripDiacritics {
+		return token
+	}
+
+	builder := strings.Builder{}
+	builder.Grow(len(token))
+
+	for _, r := range token {
+		if mapped, ok := f.mappings[r]; ok {
+			builder.WriteString(mapped)
+			continue
+		}
+
+		if !f.stripDiacritics {
+			builder.WriteRune(r)
+			continue
+		}
+
+		for _, c := range norm.NFD.String(string(r)) {
+			if !unicode.Is(unicode.Mn, c) {
+				builder.WriteRune(c)
+			}
+		}
+	}
+
+	if f.stripDiacritics {
+		return norm.NFC.String(builder.String())
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,34 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnicodeNormalizerFilter(t *testing.T) {
+	testCases := []struct {
+		normalization UnicodeNormalization
+		token         Token
+		expected      Token
+	}{
+		{UnicodeNormalization{CaseFolding: true, StripDiacritics: true}, "Škoda", "skoda"},
+		{UnicodeNormalization{CaseFolding: true, StripDiacritics: true}, "Citroën", "citroen"},
+		{UnicodeNormalization{CaseFolding: true}, "Straße", "strasse"},
+		{UnicodeNormalization{CaseFolding: true}, "Citroën", "citroën"},
+		{UnicodeNormalization{}, "ﬁat", "fiat"},
+		{UnicodeNormalization{Mappings: map[string]string{"ё": "е"}}, "ёлка", "елка"},
+		{UnicodeNormalization{StripDiacritics: true}, "йод", "иод"},
+		{UnicodeNormalization{StripDiacritics: true, Mappings: map[string]string{"й": "й"}}, "йод", "йод"},
+		{UnicodeNormalization{CaseFolding: true, Mappings: map[string]string{"Ё": "е"}}, "ЁЖ", "еж"},
+	}
+
+	for _, testCase := range testCases {
+		filter, err := NewUnicodeNormalizerFilter(testCase.normalization)
+		assert.NoError(t, err)
+		assert.Equal(t, []Token{testCase.expected}, filter.Filter([]Token{testCase.token}))
+	}
+
+	_, err := NewUnicodeNormalizerFilter(UnicodeNormalization{Mappings: map[string]string{"ss": "ß"}})
+	assert.Error(t, err)
+}
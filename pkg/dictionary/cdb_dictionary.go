@@ -11,6 +11,7 @@ import (
 // cdbDictionary implements Dictionary with cdb as database
 type cdbDictionary struct {
 	reader cdb.Reader
+	closer io.Closer
 }
 
 // NewCDBDictionary creates new instance of cdbDictionary
@@ -22,8 +23,11 @@ func NewCDBDictionary(r io.ReaderAt) (Dictionary, error) {
 		return nil, fmt.Errorf("fail to create cdb dictionary: %w", err)
 	}
 
+	closer, _ := r.(io.Closer)
+
 	return &cdbDictionary{
 		reader: reader,
+		closer: closer,
 	}, nil
 }
 
@@ -94,3 +98,16 @@ func (d *cdbDictionary) Iterate(iterator Iterator) error {
 
 	return nil
 }
+
+// Close releases the underlying reader, if it is closable.
+// The dictionary must not be used after this call
+func (d *cdbDictionary) Close() error {
+	if d.closer == nil {
+		return nil
+	}
+
+	closer := d.closer
+	d.closer = nil
+
+	return closer.Close()
+}
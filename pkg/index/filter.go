@@ -0,0 +1,73 @@
+package index
+
+import (
+	"fmt"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/merger"
+)
+
+// FilteredCollector is a collector, that accepts only the documents of its filter.
+// Searcher intersects the posting lists with the filter before merging them,
+// so the collector receives only the allowed documents
+type FilteredCollector interface {
+	merger.Collector
+	// Filter returns the set of documents allowed to be collected
+	Filter() *roaring.Bitmap
+}
+
+// filterPostingList returns the list of the given posting list documents, that belong to the filter.
+// The smaller of the list and the filter is iterated, the other one is probed
+func filterPostingList(list merger.ListIterator, filter *roaring.Bitmap) (merger.ListIterator, error) {
+	if filter.GetCardinality() < uint64(list.Len()) {
+		return lookUpFilter(list, filter)
+	}
+
+	positions := make([]uint32, 0, list.Len())
+	position, err := list.Get()
+
+	for err == nil {
+		if filter.Contains(position) {
+			positions = append(positions, position)
+		}
+
+		if !list.HasNext() {
+			break
+		}
+
+		position, err = list.Next()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate through a posting list: %w", err)
+	}
+
+	return merger.NewSliceIterator(positions), nil
+}
+
+// lookUpFilter returns the documents of the filter, that belong to the given posting list
+func lookUpFilter(list merger.ListIterator, filter *roaring.Bitmap) (merger.ListIterator, error) {
+	positions := make([]uint32, 0, filter.GetCardinality())
+	it := filter.Iterator()
+
+	for it.HasNext() {
+		target := it.Next()
+		position, err := list.LowerBound(target)
+
+		if err == merger.ErrIteratorIsNotDereferencable {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up a posting list: %w", err)
+		}
+
+		if position == target {
+			positions = append(positions, position)
+		} else {
+			it.AdvanceIfNeeded(position)
+		}
+	}
+
+	return merger.NewSliceIterator(positions), nil
+}
@@ -0,0 +1,30 @@
+package index
+
+import (
+	"testing"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/merger"
+)
+
+func TestFilterPostingList(t *testing.T) {
+	testCases := []struct {
+		name     string
+		list     []uint32
+		filter   []uint32
+		expected []uint32
+	}{
+		{"list is smaller", []uint32{1, 4, 7}, []uint32{0, 1, 2, 3, 4, 5, 6}, []uint32{1, 4}},
+		{"filter is smaller", []uint32{1, 2, 3, 5, 8, 13, 21}, []uint32{0, 4, 5, 21, 30}, []uint32{5, 21}},
+		{"no intersection", []uint32{1, 2, 3}, []uint32{10}, []uint32{}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			list, err := filterPostingList(merger.NewSliceIterator(testCase.list), roaring.BitmapOf(testCase.filter...))
+			assert.NoError(t, err)
+			assert.Equal(t, merger.NewSliceIterator(testCase.expected), list)
+		})
+	}
+}
@@ -3,7 +3,7 @@ package index
 import (
 	"encoding/gob"
 	"fmt"
-	"runtime"
+	"io"
 
 	"github.com/suggest-go/suggest/pkg/store"
 )
@@ -43,16 +43,68 @@ func (ir *Reader) Read() (InvertedIndexIndices, error) {
 	index, err := ir.createInvertedIndexIndices(header, documentReader)
 
 	if err != nil {
+		_ = documentReader.Close()
 		return nil, fmt.Errorf("failed to retrieve inverted index: %w", err)
 	}
 
-	runtime.SetFinalizer(index, func(d interface{}) {
-		documentReader.Close()
-	})
-
 	return index, nil
 }
 
+// SegmentedReader is an entity, providing access to a search index that consists of several segments
+type SegmentedReader struct {
+	directory store.Directory
+	name      string
+}
+
+// NewSegmentedIndexReader returns a new instance of a segmented search index reader
+func NewSegmentedIndexReader(
+	directory store.Directory,
+	name string,
+) *SegmentedReader {
+	return &SegmentedReader{
+		directory: directory,
+		name:      name,
+	}
+}
+
+// Read reads the segments of the index and merges them into inverted index indices
+func (sr *SegmentedReader) Read() (InvertedIndexIndices, error) {
+	infos, err := ReadSegmentInfos(sr.directory, sr.name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(infos.Segments) == 1 && infos.Segments[0].DelGen == 0 {
+		return NewIndexReader(sr.directory, SegmentConfig(infos.Segments[0].Name)).Read()
+	}
+
+	segments := make([]Segment, 0, len(infos.Segments))
+
+	for _, info := range infos.Segments {
+		indices, err := NewIndexReader(sr.directory, SegmentConfig(info.Name)).Read()
+
+		if err != nil {
+			closeSegments(segments)
+			return nil, fmt.Errorf("failed to read segment %s: %w", info.Name, err)
+		}
+
+		deletions, err := ReadDeletions(sr.directory, info)
+
+		if err != nil {
+			closeSegments(append(segments, Segment{Indices: indices}))
+			return nil, fmt.Errorf("failed to read segment %s: %w", info.Name, err)
+		}
+
+		segments = append(segments, Segment{
+			Indices:   indices,
+			Deletions: deletions,
+		})
+	}
+
+	return NewSegmentedInvertedIndexIndices(segments), nil
+}
+
 // readHeader reads an index header from the given directory
 func (ir *Reader) readHeader() (*header, error) {
 	headerReader, err := ir.directory.OpenInput(ir.config.HeaderFileName)
@@ -116,5 +168,5 @@ func (ir *Reader) createInvertedIndexIndices(header *header, documentReader stor
 		}
 	}
 
-	return NewInvertedIndexIndices(indices), nil
+	return newClosableInvertedIndexIndices(indices, []io.Closer{documentReader}), nil
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/RoaringBitmap/roaring"
 	"github.com/suggest-go/suggest/pkg/compression"
 	"github.com/suggest-go/suggest/pkg/store"
 )
@@ -25,6 +26,8 @@ type Writer struct {
 type WriterConfig struct {
 	HeaderFileName       string
 	DocumentListFileName string
+	// DocumentSetFileName is a file of the set of the indexed documents, it is not written if it is empty
+	DocumentSetFileName string
 }
 
 // NewIndexWriter returns new instance of a index writer
@@ -137,6 +140,10 @@ func (iw *Writer) Commit() error {
 		return err
 	}
 
+	if err = iw.writeDocumentSet(); err != nil {
+		return err
+	}
+
 	if err = documentWriter.Close(); err != nil {
 		return fmt.Errorf("failed to close document list: %w", err)
 	}
@@ -144,6 +151,40 @@ func (iw *Writer) Commit() error {
 	return nil
 }
 
+// writeDocumentSet writes the set of the indexed documents, so the segment writer is able
+// to tell which segments contain a document without reading their posting lists
+func (iw *Writer) writeDocumentSet() error {
+	if iw.config.DocumentSetFileName == "" {
+		return nil
+	}
+
+	documents := roaring.New()
+
+	for _, index := range iw.indices {
+		for _, postingList := range index {
+			documents.AddMany(postingList)
+		}
+	}
+
+	output, err := iw.directory.CreateOutput(iw.config.DocumentSetFileName)
+
+	if err != nil {
+		return fmt.Errorf("failed to create document set: %w", err)
+	}
+
+	documents.RunOptimize()
+
+	if _, err = documents.WriteTo(output); err != nil {
+		return fmt.Errorf("failed to encode document set: %w", err)
+	}
+
+	if err = output.Close(); err != nil {
+		return fmt.Errorf("failed to close document set: %w", err)
+	}
+
+	return nil
+}
+
 // writeHeader writes and persists index header
 func (iw *Writer) writeHeader(header header) error {
 	headerWriter, err := iw.directory.CreateOutput(iw.config.HeaderFileName)
@@ -1,5 +1,7 @@
 package index
 
+import "io"
+
 // Indices is a list of Indexes grouped by a length of a document's nGram set
 type Indices = []Index
 
@@ -15,12 +17,22 @@ type InvertedIndexIndices interface {
 
 // NewInvertedIndexIndices returns new instance of InvertedIndexIndices
 func NewInvertedIndexIndices(indices []InvertedIndex) InvertedIndexIndices {
-	return &invertedIndexIndicesImpl{indices}
+	return &invertedIndexIndicesImpl{indices: indices}
+}
+
+// newClosableInvertedIndexIndices returns new instance of InvertedIndexIndices,
+// that releases the given resources on Close
+func newClosableInvertedIndexIndices(indices []InvertedIndex, closers []io.Closer) InvertedIndexIndices {
+	return &invertedIndexIndicesImpl{
+		indices: indices,
+		closers: closers,
+	}
 }
 
 // invertedIndexIndicesImpl implements InvertedIndexIndices interface
 type invertedIndexIndicesImpl struct {
 	indices []InvertedIndex
+	closers []io.Closer
 }
 
 // Get returns InvertedIndex of term with given index.
@@ -36,3 +48,19 @@ func (i *invertedIndexIndicesImpl) Get(index int) InvertedIndex {
 func (i *invertedIndexIndicesImpl) Size() int {
 	return len(i.indices)
 }
+
+// Close releases the underlying resources of the indices.
+// The indices must not be used after this call
+func (i *invertedIndexIndicesImpl) Close() error {
+	var err error
+
+	for _, closer := range i.closers {
+		if cErr := closer.Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+
+	i.closers = nil
+
+	return err
+}
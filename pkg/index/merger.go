@@ -0,0 +1,173 @@
+package index
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/compression"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+// Merger merges all segments of a search index into a single compacted segment
+type Merger struct {
+	directory store.Directory
+	encoder   compression.Encoder
+	infos     *SegmentInfos
+	segment   string
+}
+
+// NewMerger returns a new instance of a segments merger for the index with the given name
+func NewMerger(
+	directory store.Directory,
+	name string,
+	encoder compression.Encoder,
+) (*Merger, error) {
+	infos, err := ReadSegmentInfos(directory, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Merger{
+		directory: directory,
+		encoder:   encoder,
+		infos:     infos,
+		segment:   fmt.Sprintf("%s_%d", name, infos.nextGeneration()),
+	}, nil
+}
+
+// SegmentName returns a name of the compacted segment
+func (m *Merger) SegmentName() string {
+	return m.segment
+}
+
+// Merge reads the segments and writes the compacted segment, which becomes visible only after Commit.
+// Deleted documents and documents absent in the live set are dropped, the rest documents are
+// renumbered according to their rank in the live set, so the document ids become dense
+func (m *Merger) Merge(live *roaring.Bitmap) error {
+	indices := Indices{}
+
+	for _, info := range m.infos.Segments {
+		deletions, err := ReadDeletions(m.directory, info)
+
+		if err != nil {
+			return err
+		}
+
+		if indices, err = m.mergeSegment(indices, info, live, deletions); err != nil {
+			return fmt.Errorf("failed to merge segment %s: %w", info.Name, err)
+		}
+	}
+
+	for _, index := range indices {
+		for term, postingList := range index {
+			sort.Slice(postingList, func(i, j int) bool { return postingList[i] < postingList[j] })
+			index[term] = postingList
+		}
+	}
+
+	writer := NewIndexWriter(m.directory, SegmentConfig(m.segment), m.encoder)
+	writer.indices = indices
+
+	if err := writer.Commit(); err != nil {
+		return fmt.Errorf("failed to write segment %s: %w", m.segment, err)
+	}
+
+	return nil
+}
+
+// Commit atomically replaces all merged segments with the compacted one.
+// Returns the list of replaced segments, so the caller is able to clean up the related data
+func (m *Merger) Commit() ([]SegmentInfo, error) {
+	replaced := m.infos.Segments
+	m.infos.Segments = []SegmentInfo{{Name: m.segment}}
+
+	if err := m.infos.Commit(m.directory); err != nil {
+		return nil, err
+	}
+
+	for _, info := range replaced {
+		config := SegmentConfig(info.Name)
+		removeFiles(m.directory, config.HeaderFileName, config.DocumentListFileName, config.DocumentSetFileName)
+
+		if info.DelGen > 0 {
+			removeFiles(m.directory, deletionsFileName(info))
+		}
+	}
+
+	return replaced, nil
+}
+
+// mergeSegment appends the live renumbered postings of the segment to the given indices
+func (m *Merger) mergeSegment(indices Indices, info SegmentInfo, live, deletions *roaring.Bitmap) (Indices, error) {
+	reader := NewIndexReader(m.directory, SegmentConfig(info.Name))
+	header, err := reader.readHeader()
+
+	if err != nil {
+		return nil, err
+	}
+
+	documentReader, err := m.directory.OpenInput(reader.config.DocumentListFileName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document list: %w", err)
+	}
+
+	defer documentReader.Close()
+
+	for len(indices) < int(header.Indices) {
+		indices = append(indices, nil)
+	}
+
+	for _, description := range header.Terms {
+		if description.PostingListBytesSize == 0 {
+			continue
+		}
+
+		input, err := documentReader.Slice(int64(description.PostingListPosition), int64(description.PostingListBytesSize))
+
+		if err != nil {
+			return nil, err
+		}
+
+		postingListContext := PostingListContext{
+			ListSize: int(description.PostingListLen),
+			Reader:   input,
+		}
+
+		list := resolvePostingList(postingListContext)
+
+		if err := list.Init(postingListContext); err != nil {
+			return nil, fmt.Errorf("failed to initialize a posting list iterator: %w", err)
+		}
+
+		positions, err := appendLivePositions(nil, list, deletions)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := releasePostingList(list); err != nil {
+			return nil, err
+		}
+
+		index := indices[description.Indice]
+
+		for _, position := range positions {
+			if !live.Contains(position) {
+				continue
+			}
+
+			if index == nil {
+				index = make(Index)
+				indices[description.Indice] = index
+			}
+
+			// Rank returns the number of integers that are smaller or equal to the position
+			index[description.Term] = append(index[description.Term], Position(live.Rank(position)-1))
+		}
+	}
+
+	return indices, nil
+}
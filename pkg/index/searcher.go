@@ -1,15 +1,19 @@
 package index
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/RoaringBitmap/roaring"
 	"github.com/suggest-go/suggest/pkg/merger"
 )
 
 // Searcher is responsible for searching
 type Searcher interface {
-	// Search performs search for the given index with the terms and threshold
-	Search(invertedIndex InvertedIndex, terms []Term, threshold int, collector merger.Collector) error
+	// Search performs search for the given index with the terms and threshold.
+	// If the collector is a FilteredCollector, only the documents of its filter are found.
+	// The search is interrupted with the context error once the context is done
+	Search(ctx context.Context, invertedIndex InvertedIndex, terms []Term, threshold int, collector merger.Collector) error
 }
 
 // searcher implements the Searcher interface
@@ -25,7 +29,56 @@ func NewSearcher(merger merger.ListMerger) Searcher {
 }
 
 // Search performs search for the given index with the terms and threshold
-func (s *searcher) Search(invertedIndex InvertedIndex, terms []Term, threshold int, collector merger.Collector) error {
+func (s *searcher) Search(ctx context.Context, invertedIndex InvertedIndex, terms []Term, threshold int, collector merger.Collector) error {
+	var filter *roaring.Bitmap
+
+	if filtered, ok := collector.(FilteredCollector); ok {
+		filter = filtered.Filter()
+
+		if filter.IsEmpty() {
+			return nil
+		}
+	}
+
+	segmented, ok := invertedIndex.(*segmentedInvertedIndex)
+
+	if !ok {
+		return s.search(ctx, invertedIndex, terms, threshold, filter, collector)
+	}
+
+	// each live document belongs to the only segment, so we can look up the segments separately
+	for _, part := range segmented.parts {
+		segmentCollector := collector
+
+		if part.deletions != nil {
+			segmentCollector = &deletionsCollector{
+				collector: collector,
+				deletions: part.deletions,
+			}
+		}
+
+		if err := s.search(ctx, part.invertedIndex, terms, threshold, filter, segmentCollector); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// search performs search for the given single segment index with the terms and threshold,
+// the posting lists are intersected with the filter, if it is set
+func (s *searcher) search(
+	ctx context.Context,
+	invertedIndex InvertedIndex,
+	terms []Term,
+	threshold int,
+	filter *roaring.Bitmap,
+	collector merger.Collector,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
 	terms = filterTermsByExistence(invertedIndex, terms, threshold)
 	n := len(terms)
 
@@ -54,10 +107,27 @@ func (s *searcher) Search(invertedIndex InvertedIndex, terms []Term, threshold i
 			return fmt.Errorf("failed to initialize a posting list iterator: %w", err)
 		}
 
-		rid = append(rid, list)
+		if filter == nil {
+			rid = append(rid, list)
+			continue
+		}
+
+		filtered, err := filterPostingList(list, filter)
+
+		if err != nil {
+			return err
+		}
+
+		if filtered.Len() > 0 {
+			rid = append(rid, filtered)
+		}
+	}
+
+	if len(rid) < threshold {
+		return nil
 	}
 
-	if err := s.merger.Merge(rid, threshold, collector); err != nil {
+	if err := s.merger.Merge(ctx, rid, threshold, collector); err != nil {
 		return fmt.Errorf("failed to merge posting lists: %w", err)
 	}
 
@@ -0,0 +1,238 @@
+package index
+
+import (
+	"encoding/gob"
+	"fmt"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+// SegmentInfo describes a single segment of a search index
+type SegmentInfo struct {
+	// Name is a common prefix of the segment files
+	Name string
+	// DelGen is a generation of the segment deletions file, 0 means that the segment has no deletions
+	DelGen uint32
+}
+
+// SegmentInfos is a list of segments that form a search index.
+// An index without a segments file consists of the only base segment
+type SegmentInfos struct {
+	Version    string
+	Name       string
+	Generation uint32
+	Segments   []SegmentInfo
+}
+
+// NewSegmentInfos returns a new instance of SegmentInfos that contains the only base segment
+func NewSegmentInfos(name string) *SegmentInfos {
+	return &SegmentInfos{
+		Version:  IndexVersion,
+		Name:     name,
+		Segments: []SegmentInfo{{Name: name}},
+	}
+}
+
+// SegmentConfig returns a writer config for the segment with the given name
+func SegmentConfig(segment string) WriterConfig {
+	return WriterConfig{
+		HeaderFileName:       fmt.Sprintf("%s.hd", segment),
+		DocumentListFileName: fmt.Sprintf("%s.dl", segment),
+		DocumentSetFileName:  fmt.Sprintf("%s.ids", segment),
+	}
+}
+
+// ReadSegmentInfos reads a list of segments of the index with the given name
+func ReadSegmentInfos(directory store.Directory, name string) (*SegmentInfos, error) {
+	fileName := segmentsFileName(name)
+
+	if !directory.Exists(fileName) {
+		return NewSegmentInfos(name), nil
+	}
+
+	input, err := directory.OpenInput(fileName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open segments file: %w", err)
+	}
+
+	infos := &SegmentInfos{}
+
+	if err = gob.NewDecoder(input).Decode(infos); err != nil {
+		return nil, fmt.Errorf("failed to retrieve segments: %w", err)
+	}
+
+	if infos.Version != IndexVersion {
+		return nil, fmt.Errorf("segments version mismatch, expected %s version", IndexVersion)
+	}
+
+	if err = input.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close segments file: %w", err)
+	}
+
+	return infos, nil
+}
+
+// Commit atomically persists the list of segments
+func (si *SegmentInfos) Commit(directory store.Directory) error {
+	fileName := segmentsFileName(si.Name)
+	tmpFileName := fileName + ".tmp"
+	output, err := directory.CreateOutput(tmpFileName)
+
+	if err != nil {
+		return fmt.Errorf("failed to create segments file: %w", err)
+	}
+
+	if err = gob.NewEncoder(output).Encode(si); err != nil {
+		return fmt.Errorf("failed to encode segments: %w", err)
+	}
+
+	if err = output.Close(); err != nil {
+		return fmt.Errorf("failed to close segments file: %w", err)
+	}
+
+	if err = directory.Rename(tmpFileName, fileName); err != nil {
+		return fmt.Errorf("failed to commit segments file: %w", err)
+	}
+
+	return nil
+}
+
+// nextGeneration increments and returns the generation of the segments
+func (si *SegmentInfos) nextGeneration() uint32 {
+	si.Generation++
+
+	return si.Generation
+}
+
+// ReadDeletions reads a set of deleted documents of the given segment
+func ReadDeletions(directory store.Directory, info SegmentInfo) (*roaring.Bitmap, error) {
+	deletions := roaring.New()
+
+	if info.DelGen == 0 {
+		return deletions, nil
+	}
+
+	input, err := directory.OpenInput(deletionsFileName(info))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open deletions file: %w", err)
+	}
+
+	if _, err = deletions.ReadFrom(input); err != nil {
+		return nil, fmt.Errorf("failed to retrieve deletions: %w", err)
+	}
+
+	if err = input.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close deletions file: %w", err)
+	}
+
+	return deletions, nil
+}
+
+// readDocumentSet reads the set of the documents of the given segment.
+// Returns nil if the segment has been written without the document set
+func readDocumentSet(directory store.Directory, info SegmentInfo) (*roaring.Bitmap, error) {
+	fileName := SegmentConfig(info.Name).DocumentSetFileName
+
+	if !directory.Exists(fileName) {
+		return nil, nil
+	}
+
+	input, err := directory.OpenInput(fileName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document set: %w", err)
+	}
+
+	documents := roaring.New()
+
+	if _, err = documents.ReadFrom(input); err != nil {
+		return nil, fmt.Errorf("failed to retrieve document set: %w", err)
+	}
+
+	if err = input.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close document set: %w", err)
+	}
+
+	return documents, nil
+}
+
+// writeDeletions persists the set of deleted documents of the given segment
+func writeDeletions(directory store.Directory, info SegmentInfo, deletions *roaring.Bitmap) error {
+	output, err := directory.CreateOutput(deletionsFileName(info))
+
+	if err != nil {
+		return fmt.Errorf("failed to create deletions file: %w", err)
+	}
+
+	deletions.RunOptimize()
+
+	if _, err = deletions.WriteTo(output); err != nil {
+		return fmt.Errorf("failed to encode deletions: %w", err)
+	}
+
+	if err = output.Close(); err != nil {
+		return fmt.Errorf("failed to close deletions file: %w", err)
+	}
+
+	return nil
+}
+
+// ResetSegments drops all segments of the index with the given name except the base one and advances
+// the generation of the segments, as the base segment is supposed to be rebuilt.
+// Returns the list of dropped segments, so the caller is able to clean up the related data
+func ResetSegments(directory store.Directory, name string) ([]SegmentInfo, error) {
+	infos, err := ReadSegmentInfos(directory, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	fresh := NewSegmentInfos(name)
+	fresh.Generation = infos.Generation
+	fresh.nextGeneration()
+
+	if err = fresh.Commit(directory); err != nil {
+		return nil, err
+	}
+
+	dropped := []SegmentInfo{}
+
+	for _, info := range infos.Segments {
+		if info.DelGen > 0 {
+			removeFiles(directory, deletionsFileName(info))
+		}
+
+		if info.Name == name {
+			continue
+		}
+
+		config := SegmentConfig(info.Name)
+		removeFiles(directory, config.HeaderFileName, config.DocumentListFileName, config.DocumentSetFileName)
+		dropped = append(dropped, info)
+	}
+
+	return dropped, nil
+}
+
+// segmentsFileName returns a name of the file that stores the list of segments
+func segmentsFileName(name string) string {
+	return fmt.Sprintf("%s.seg", name)
+}
+
+// deletionsFileName returns a name of the file that stores deletions of the segment
+func deletionsFileName(info SegmentInfo) string {
+	return fmt.Sprintf("%s_%d.del", info.Name, info.DelGen)
+}
+
+// removeFiles removes the given files, the files that are still opened by
+// readers are kept by the OS until they will be closed
+func removeFiles(directory store.Directory, names ...string) {
+	for _, name := range names {
+		if directory.Exists(name) {
+			_ = directory.Remove(name)
+		}
+	}
+}
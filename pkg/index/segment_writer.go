@@ -0,0 +1,164 @@
+package index
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/compression"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+// SegmentWriter applies incremental changes to an already built search index.
+// Added documents are written as a new delta segment, deleted and replaced documents
+// of the previous segments are marked in their deletion bitmaps
+type SegmentWriter struct {
+	directory store.Directory
+	encoder   compression.Encoder
+	infos     *SegmentInfos
+	segment   string
+	documents map[DocumentID][]Term
+	deletions *roaring.Bitmap
+}
+
+// NewSegmentWriter returns a new instance of a segment writer for the index with the given name
+func NewSegmentWriter(
+	directory store.Directory,
+	name string,
+	encoder compression.Encoder,
+) (*SegmentWriter, error) {
+	infos, err := ReadSegmentInfos(directory, name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &SegmentWriter{
+		directory: directory,
+		encoder:   encoder,
+		infos:     infos,
+		segment:   fmt.Sprintf("%s_%d", name, infos.nextGeneration()),
+		documents: make(map[DocumentID][]Term),
+		deletions: roaring.New(),
+	}, nil
+}
+
+// SegmentName returns a name of the segment that is going to be created on Commit
+func (sw *SegmentWriter) SegmentName() string {
+	return sw.segment
+}
+
+// AddDocument adds a new document with the given terms,
+// the document with the same id is replaced
+func (sw *SegmentWriter) AddDocument(id DocumentID, terms []Term) error {
+	sw.documents[id] = terms
+	sw.deletions.Add(id)
+
+	return nil
+}
+
+// DeleteDocument deletes the document with the given id
+func (sw *SegmentWriter) DeleteDocument(id DocumentID) error {
+	delete(sw.documents, id)
+	sw.deletions.Add(id)
+
+	return nil
+}
+
+// Commit persists the added documents as a new segment and the deletions of the previous segments
+func (sw *SegmentWriter) Commit() error {
+	staleFiles := []string{}
+	generation := sw.infos.Generation
+
+	if !sw.deletions.IsEmpty() {
+		for i, info := range sw.infos.Segments {
+			deleted, err := sw.segmentDeletions(info)
+
+			if err != nil {
+				return err
+			}
+
+			deletions, err := ReadDeletions(sw.directory, info)
+
+			if err != nil {
+				return err
+			}
+
+			// the segment doesn't contain the deleted documents or they have already been deleted
+			if roaring.AndNot(deleted, deletions).IsEmpty() {
+				continue
+			}
+
+			if info.DelGen > 0 {
+				staleFiles = append(staleFiles, deletionsFileName(info))
+			}
+
+			deletions.Or(deleted)
+			info.DelGen = generation
+
+			if err := writeDeletions(sw.directory, info, deletions); err != nil {
+				return err
+			}
+
+			sw.infos.Segments[i] = info
+		}
+	}
+
+	if len(sw.documents) > 0 {
+		if err := sw.writeSegment(); err != nil {
+			return err
+		}
+
+		sw.infos.Segments = append(sw.infos.Segments, SegmentInfo{Name: sw.segment})
+	}
+
+	if err := sw.infos.Commit(sw.directory); err != nil {
+		return err
+	}
+
+	removeFiles(sw.directory, staleFiles...)
+
+	return nil
+}
+
+// segmentDeletions returns the deleted documents, that the given segment contains.
+// All deletions are returned for a segment written without the document set
+func (sw *SegmentWriter) segmentDeletions(info SegmentInfo) (*roaring.Bitmap, error) {
+	documents, err := readDocumentSet(sw.directory, info)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if documents == nil {
+		return sw.deletions, nil
+	}
+
+	return roaring.And(sw.deletions, documents), nil
+}
+
+// writeSegment writes the added documents as a new segment
+func (sw *SegmentWriter) writeSegment() error {
+	ids := make([]DocumentID, 0, len(sw.documents))
+
+	for id := range sw.documents {
+		ids = append(ids, id)
+	}
+
+	// posting lists have to be sorted
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	writer := NewIndexWriter(sw.directory, SegmentConfig(sw.segment), sw.encoder)
+
+	for _, id := range ids {
+		if err := writer.AddDocument(id, sw.documents[id]); err != nil {
+			return err
+		}
+	}
+
+	if err := writer.Commit(); err != nil {
+		return fmt.Errorf("failed to write segment %s: %w", sw.segment, err)
+	}
+
+	return nil
+}
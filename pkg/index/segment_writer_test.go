@@ -0,0 +1,128 @@
+package index
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/merger"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+func TestSegmentWriter(t *testing.T) {
+	directory := store.NewRAMDirectory()
+	encoder, err := NewEncoder()
+	assert.NoError(t, err)
+
+	writer := NewIndexWriter(directory, SegmentConfig("test"), encoder)
+	assert.NoError(t, writer.AddDocument(0, []Term{"a", "b"}))
+	assert.NoError(t, writer.AddDocument(1, []Term{"a", "c"}))
+	assert.NoError(t, writer.AddDocument(2, []Term{"b", "c"}))
+	assert.NoError(t, writer.Commit())
+
+	segmentWriter, err := NewSegmentWriter(directory, "test", encoder)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_1", segmentWriter.SegmentName())
+
+	// replace the document 0, delete the document 2 and add a new one
+	assert.NoError(t, segmentWriter.AddDocument(0, []Term{"c", "d"}))
+	assert.NoError(t, segmentWriter.DeleteDocument(2))
+	assert.NoError(t, segmentWriter.AddDocument(3, []Term{"a", "d"}))
+	assert.NoError(t, segmentWriter.Commit())
+
+	indices, err := NewSegmentedIndexReader(directory, "test").Read()
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		terms     []Term
+		threshold int
+		expected  []Position
+	}{
+		{[]Term{"a"}, 1, []Position{1, 3}},
+		{[]Term{"b"}, 1, []Position{}},
+		{[]Term{"c"}, 1, []Position{0, 1}},
+		{[]Term{"c", "d"}, 2, []Position{0}},
+		{[]Term{"a", "d"}, 1, []Position{0, 1, 3}},
+	}
+
+	searcher := NewSearcher(merger.CPMerge())
+
+	for _, testCase := range testCases {
+		collector := &merger.SimpleCollector{}
+		err := searcher.Search(context.Background(), indices.Get(2), testCase.terms, testCase.threshold, collector)
+		assert.NoError(t, err)
+
+		actual := []Position{}
+
+		for _, candidate := range collector.Candidates {
+			actual = append(actual, candidate.Position())
+		}
+
+		assert.ElementsMatch(t, testCase.expected, actual)
+	}
+
+	postingListContext, err := indices.Get(2).Get("c")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, postingListContext.ListSize)
+
+	// the document 2 is deleted, but it is counted until the segments are merged
+	df, err := DocumentFrequency(indices.Get(2), "c")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, df)
+
+	df, err = DocumentFrequency(indices.Get(2), "e")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, df)
+
+	dropped, err := ResetSegments(directory, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, []SegmentInfo{{Name: "test_1"}}, dropped)
+
+	indices, err = NewSegmentedIndexReader(directory, "test").Read()
+	assert.NoError(t, err)
+
+	collector := &merger.SimpleCollector{}
+	assert.NoError(t, NewSearcher(merger.CPMerge()).Search(context.Background(), indices.Get(2), []Term{"b"}, 1, collector))
+	assert.Len(t, collector.Candidates, 2)
+}
+
+func TestSegmentWriterDeletionsOfContainingSegments(t *testing.T) {
+	directory := store.NewRAMDirectory()
+	encoder, err := NewEncoder()
+	assert.NoError(t, err)
+
+	writer := NewIndexWriter(directory, SegmentConfig("test"), encoder)
+	assert.NoError(t, writer.AddDocument(0, []Term{"a", "b"}))
+	assert.NoError(t, writer.AddDocument(1, []Term{"a", "c"}))
+	assert.NoError(t, writer.Commit())
+
+	segmentWriter, err := NewSegmentWriter(directory, "test", encoder)
+	assert.NoError(t, err)
+	assert.NoError(t, segmentWriter.AddDocument(5, []Term{"c", "d"}))
+	assert.NoError(t, segmentWriter.Commit())
+
+	// the document 5 is replaced, it lives only in the delta segment
+	segmentWriter, err = NewSegmentWriter(directory, "test", encoder)
+	assert.NoError(t, err)
+	assert.NoError(t, segmentWriter.AddDocument(5, []Term{"b", "d"}))
+	assert.NoError(t, segmentWriter.Commit())
+
+	infos, err := ReadSegmentInfos(directory, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, []SegmentInfo{{Name: "test"}, {Name: "test_1", DelGen: 2}, {Name: "test_2"}}, infos.Segments)
+
+	// the deletion of an already deleted document doesn't rewrite the deletions
+	segmentWriter, err = NewSegmentWriter(directory, "test", encoder)
+	assert.NoError(t, err)
+	assert.NoError(t, segmentWriter.DeleteDocument(1))
+	assert.NoError(t, segmentWriter.Commit())
+
+	segmentWriter, err = NewSegmentWriter(directory, "test", encoder)
+	assert.NoError(t, err)
+	assert.NoError(t, segmentWriter.DeleteDocument(1))
+	assert.NoError(t, segmentWriter.Commit())
+
+	infos, err = ReadSegmentInfos(directory, "test")
+	assert.NoError(t, err)
+	assert.Equal(t, []SegmentInfo{{Name: "test", DelGen: 3}, {Name: "test_1", DelGen: 2}, {Name: "test_2"}}, infos.Segments)
+}
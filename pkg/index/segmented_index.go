@@ -0,0 +1,237 @@
+package index
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/merger"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+// Segment is a part of a search index, that has been written by a single commit
+type Segment struct {
+	// Indices is an inverted index of the segment
+	Indices InvertedIndexIndices
+	// Deletions is a set of the segment documents that have been deleted or replaced by the next segments
+	Deletions *roaring.Bitmap
+}
+
+// NewSegmentedInvertedIndexIndices returns a new instance of InvertedIndexIndices,
+// that merges the given segments on the fly
+func NewSegmentedInvertedIndexIndices(segments []Segment) InvertedIndexIndices {
+	size := 0
+
+	for _, segment := range segments {
+		if segment.Indices.Size() > size {
+			size = segment.Indices.Size()
+		}
+	}
+
+	indices := make([]InvertedIndex, size)
+	closers := make([]io.Closer, 0, len(segments))
+
+	for _, segment := range segments {
+		if closer, ok := segment.Indices.(io.Closer); ok {
+			closers = append(closers, closer)
+		}
+	}
+
+	for i := range indices {
+		parts := []segmentPart{}
+
+		for _, segment := range segments {
+			invertedIndex := segment.Indices.Get(i)
+
+			if invertedIndex == nil {
+				continue
+			}
+
+			var deletions *roaring.Bitmap
+
+			if segment.Deletions != nil && !segment.Deletions.IsEmpty() {
+				deletions = segment.Deletions
+			}
+
+			parts = append(parts, segmentPart{
+				invertedIndex: invertedIndex,
+				deletions:     deletions,
+			})
+		}
+
+		switch {
+		case len(parts) == 0:
+			indices[i] = nil
+		case len(parts) == 1 && parts[0].deletions == nil:
+			indices[i] = parts[0].invertedIndex
+		default:
+			indices[i] = &segmentedInvertedIndex{parts: parts}
+		}
+	}
+
+	return newClosableInvertedIndexIndices(indices, closers)
+}
+
+// closeSegments releases the resources of the given segments
+func closeSegments(segments []Segment) {
+	for _, segment := range segments {
+		if closer, ok := segment.Indices.(io.Closer); ok {
+			_ = closer.Close()
+		}
+	}
+}
+
+// segmentPart is an inverted index of the segment with the list of its deleted documents
+type segmentPart struct {
+	invertedIndex InvertedIndex
+	deletions     *roaring.Bitmap
+}
+
+// segmentedInvertedIndex implements InvertedIndex interface for a list of segments
+type segmentedInvertedIndex struct {
+	parts []segmentPart
+}
+
+// Get returns corresponding posting list for given term.
+// Note, that the posting lists of the segments are decoded and merged in memory here,
+// Searcher uses a faster way and looks up each segment separately
+func (i *segmentedInvertedIndex) Get(term Term) (PostingListContext, error) {
+	positions := []Position{}
+
+	for _, part := range i.parts {
+		if !part.invertedIndex.Has(term) {
+			continue
+		}
+
+		postingListContext, err := part.invertedIndex.Get(term)
+
+		if err != nil {
+			return PostingListContext{}, err
+		}
+
+		list := resolvePostingList(postingListContext)
+
+		if err := list.Init(postingListContext); err != nil {
+			return PostingListContext{}, fmt.Errorf("failed to initialize a posting list iterator: %w", err)
+		}
+
+		positions, err = appendLivePositions(positions, list, part.deletions)
+
+		if err != nil {
+			return PostingListContext{}, err
+		}
+
+		if err := releasePostingList(list); err != nil {
+			return PostingListContext{}, err
+		}
+	}
+
+	if len(positions) == 0 {
+		return PostingListContext{}, nil
+	}
+
+	sort.Slice(positions, func(a, b int) bool { return positions[a] < positions[b] })
+
+	encoder, err := NewEncoder()
+
+	if err != nil {
+		return PostingListContext{}, err
+	}
+
+	buf := &bytes.Buffer{}
+
+	if _, err := encoder.Encode(positions, store.NewBytesOutput(buf)); err != nil {
+		return PostingListContext{}, fmt.Errorf("failed to encode merged posting list: %w", err)
+	}
+
+	return PostingListContext{
+		ListSize: len(positions),
+		Reader:   store.NewBytesInput(buf.Bytes()),
+	}, nil
+}
+
+// Has checks is there is given term in inverted index
+func (i *segmentedInvertedIndex) Has(term Term) bool {
+	for _, part := range i.parts {
+		if part.invertedIndex.Has(term) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DocumentFrequency returns the number of documents of the inverted index, that contain the term.
+// The posting lists aren't decoded, the sizes of the segment lists are summed up instead, so the
+// documents deleted from a segment are still counted until the segments are merged
+func DocumentFrequency(invertedIndex InvertedIndex, term Term) (int, error) {
+	segmented, ok := invertedIndex.(*segmentedInvertedIndex)
+
+	if !ok {
+		if !invertedIndex.Has(term) {
+			return 0, nil
+		}
+
+		postingListContext, err := invertedIndex.Get(term)
+
+		if err != nil {
+			return 0, err
+		}
+
+		return postingListContext.ListSize, nil
+	}
+
+	df := 0
+
+	for _, part := range segmented.parts {
+		n, err := DocumentFrequency(part.invertedIndex, term)
+
+		if err != nil {
+			return 0, err
+		}
+
+		df += n
+	}
+
+	return df, nil
+}
+
+// appendLivePositions appends positions of the given list, that are not deleted, to the slice
+func appendLivePositions(positions []Position, list merger.ListIterator, deletions *roaring.Bitmap) ([]Position, error) {
+	current, err := list.Get()
+
+	for err == nil {
+		if deletions == nil || !deletions.Contains(current) {
+			positions = append(positions, current)
+		}
+
+		if !list.HasNext() {
+			break
+		}
+
+		current, err = list.Next()
+	}
+
+	if err != nil && err != merger.ErrIteratorIsNotDereferencable {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// deletionsCollector skips deleted documents of a segment
+type deletionsCollector struct {
+	collector merger.Collector
+	deletions *roaring.Bitmap
+}
+
+// Collect collects the given candidate
+func (c *deletionsCollector) Collect(candidate merger.MergeCandidate) error {
+	if c.deletions.Contains(candidate.Position()) {
+		return nil
+	}
+
+	return c.collector.Collect(candidate)
+}
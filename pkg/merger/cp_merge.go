@@ -1,6 +1,7 @@
 package merger
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"sync"
@@ -16,10 +17,10 @@ func CPMerge() ListMerger {
 type cpMerge struct{}
 
 // Merge returns list of candidates, that appears at least `threshold` times.
-func (cp *cpMerge) Merge(rid Rid, threshold int, collector Collector) error {
+func (cp *cpMerge) Merge(ctx context.Context, rid Rid, threshold int, collector Collector) error {
 	lenRid := len(rid)
 	minQueries := lenRid - threshold + 1
-	j, endMergeCandidate := 0, 0
+	j, endMergeCandidate, steps := 0, 0, 0
 
 	sort.Sort(rid)
 
@@ -44,7 +45,13 @@ func (cp *cpMerge) Merge(rid Rid, threshold int, collector Collector) error {
 		tmp = tmp[:0]
 		j, endMergeCandidate = 0, len(candidates)
 
-		for j < endMergeCandidate || isValid {
+		for ; j < endMergeCandidate || isValid; steps++ {
+			if steps%contextCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+
 			if j >= endMergeCandidate || (isValid && candidates[j].Position() > current) {
 				tmp = append(tmp, NewMergeCandidate(current, 1))
 
@@ -84,6 +91,13 @@ func (cp *cpMerge) Merge(rid Rid, threshold int, collector Collector) error {
 		tmp = tmp[:0]
 
 		for _, c := range candidates {
+			if steps%contextCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+
+			steps++
 			current, err := rid[i].LowerBound(c.Position())
 
 			if err == nil && current == c.Position() {
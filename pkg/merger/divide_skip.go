@@ -1,6 +1,7 @@
 package merger
 
 import (
+	"context"
 	"math"
 	"sort"
 )
@@ -22,7 +23,7 @@ type divideSkip struct {
 }
 
 // Merge returns list of candidates, that appears at least `threshold` times.
-func (ds *divideSkip) Merge(rid Rid, threshold int, collector Collector) error {
+func (ds *divideSkip) Merge(ctx context.Context, rid Rid, threshold int, collector Collector) error {
 	sort.Sort(sort.Reverse(rid))
 
 	M := float64(rid[0].Len())
@@ -32,17 +33,23 @@ func (ds *divideSkip) Merge(rid Rid, threshold int, collector Collector) error {
 	lShort := rid[l:]
 
 	if len(lShort) == 0 {
-		return ds.merger.Merge(rid, threshold, collector)
+		return ds.merger.Merge(ctx, rid, threshold, collector)
 	}
 
 	mergeRes := &SimpleCollector{}
-	err := ds.merger.Merge(lShort, threshold-l, mergeRes)
+	err := ds.merger.Merge(ctx, lShort, threshold-l, mergeRes)
 
 	if err != nil {
 		return err
 	}
 
-	for _, c := range mergeRes.Candidates {
+	for i, c := range mergeRes.Candidates {
+		if i%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
 		position := c.Position()
 
 		for _, longList := range lLong {
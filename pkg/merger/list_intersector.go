@@ -1,13 +1,17 @@
 package merger
 
-import "sort"
+import (
+	"context"
+	"sort"
+)
 
 // ListIntersector is the interface that is responsible for intersection operation
 // between array of docs iterators
 type ListIntersector interface {
 	// Intersect performs intersection operation for the given rid and
-	// transmits the result to collector
-	Intersect(rid Rid, collector Collector) error
+	// transmits the result to collector. The intersection is interrupted with
+	// the context error once the context is done
+	Intersect(ctx context.Context, rid Rid, collector Collector) error
 }
 
 // intersector implements ListIntersector interface
@@ -20,7 +24,7 @@ func Intersector() ListIntersector {
 
 // Intersect performs intersection operation for the given rid and
 // transmits the result to collector
-func (i *intersector) Intersect(rid Rid, collector Collector) error {
+func (i *intersector) Intersect(ctx context.Context, rid Rid, collector Collector) error {
 	n := uint32(len(rid))
 
 	if n == 0 {
@@ -37,7 +41,13 @@ func (i *intersector) Intersect(rid Rid, collector Collector) error {
 		return err
 	}
 
-	for {
+	for steps := 0; ; steps++ {
+		if steps%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
 		isGoodCandidate := true
 
 		for _, it := range rest {
@@ -1,6 +1,7 @@
 package merger
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -55,7 +56,7 @@ func TestIntersect(t *testing.T) {
 		}
 
 		collector := &SimpleCollector{}
-		assert.NoError(t, intersector.Intersect(rid, collector))
+		assert.NoError(t, intersector.Intersect(context.Background(), rid, collector))
 
 		actual := []uint32{}
 
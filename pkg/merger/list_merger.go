@@ -3,17 +3,26 @@
 // - Find the set of string ids that appear at least T times on the inverted lists, where T is a constant.
 package merger
 
-import "github.com/suggest-go/suggest/pkg/utils"
+import (
+	"context"
+
+	"github.com/suggest-go/suggest/pkg/utils"
+)
 
 // MaxOverlap is the largest value of an overlap count for a merge candidate
 const MaxOverlap = 0xFFFF
 
+// contextCheckInterval is the number of posting list records, that are processed between
+// two checks of the context of a merge
+const contextCheckInterval = 1024
+
 // ListMerger solves `threshold`-occurrence problem:
 // For given inverted lists find the set of strings ids, that appears at least
 // `threshold` times.
 type ListMerger interface {
 	// Merge returns list of candidates, that appears at least `threshold` times.
-	Merge(rid Rid, threshold int, collector Collector) error
+	// The merge is interrupted with the context error once the context is done
+	Merge(ctx context.Context, rid Rid, threshold int, collector Collector) error
 }
 
 // Rid represents inverted lists for ListMerger
@@ -70,7 +79,7 @@ func newMerger(merger ListMerger) ListMerger {
 }
 
 // Merge returns list of candidates, that appears at least `threshold` times.
-func (m *mergerOptimizer) Merge(rid Rid, threshold int, collector Collector) error {
+func (m *mergerOptimizer) Merge(ctx context.Context, rid Rid, threshold int, collector Collector) error {
 	n := len(rid)
 
 	if n < threshold || n == 0 || threshold < 0 {
@@ -78,8 +87,8 @@ func (m *mergerOptimizer) Merge(rid Rid, threshold int, collector Collector) err
 	}
 
 	if n == threshold {
-		return m.intersector.Intersect(rid, collector)
+		return m.intersector.Intersect(ctx, rid, collector)
 	}
 
-	return m.merger.Merge(rid, threshold, collector)
+	return m.merger.Merge(ctx, rid, threshold, collector)
 }
@@ -1,6 +1,7 @@
 package merger
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -161,7 +162,7 @@ func TestMerge(t *testing.T) {
 				}
 
 				collector := &SimpleCollector{}
-				err := data.merger.Merge(rid, testCase.t, collector)
+				err := data.merger.Merge(context.Background(), rid, testCase.t, collector)
 				assert.NoError(t, err)
 
 				for _, candidate := range collector.Candidates {
@@ -173,3 +174,52 @@ func TestMerge(t *testing.T) {
 		}
 	}
 }
+
+func TestMergeCanceled(t *testing.T) {
+	mergers := []ListMerger{ScanCount(), CPMerge(), MergeSkip(), DivideSkip(0.01)}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for _, merger := range mergers {
+		rid := Rid{
+			NewSliceIterator([]uint32{1, 2, 3}),
+			NewSliceIterator([]uint32{1, 3, 5}),
+			NewSliceIterator([]uint32{2, 3, 4}),
+		}
+
+		collector := &SimpleCollector{}
+		assert.Equal(t, context.Canceled, merger.Merge(ctx, rid, 2, collector))
+		assert.Empty(t, collector.Candidates)
+	}
+}
+
+func TestMergeCanceledWithinList(t *testing.T) {
+	for _, merger := range []ListMerger{ScanCount(), CPMerge()} {
+		ctx, cancel := context.WithCancel(context.Background())
+		list := make([]uint32, 4*contextCheckInterval)
+
+		for i := range list {
+			list[i] = uint32(i)
+		}
+
+		rid := Rid{
+			&cancelingIterator{ListIterator: NewSliceIterator(list), cancel: cancel},
+			NewSliceIterator([]uint32{1, 3, 5}),
+		}
+
+		collector := &SimpleCollector{}
+		assert.Equal(t, context.Canceled, merger.Merge(ctx, rid, 1, collector))
+		assert.Empty(t, collector.Candidates)
+	}
+}
+
+// cancelingIterator is a ListIterator that cancels the context once it is moved to the next record
+type cancelingIterator struct {
+	ListIterator
+	cancel context.CancelFunc
+}
+
+func (i *cancelingIterator) Next() (uint32, error) {
+	i.cancel()
+	return i.ListIterator.Next()
+}
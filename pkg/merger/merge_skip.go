@@ -1,6 +1,9 @@
 package merger
 
-import "container/heap"
+import (
+	"container/heap"
+	"context"
+)
 
 type record struct {
 	ridID    uint32
@@ -49,7 +52,7 @@ func MergeSkip() ListMerger {
 type mergeSkip struct{}
 
 // Merge returns list of candidates, that appears at least `threshold` times.
-func (ms *mergeSkip) Merge(rid Rid, threshold int, collector Collector) error {
+func (ms *mergeSkip) Merge(ctx context.Context, rid Rid, threshold int, collector Collector) error {
 	var (
 		lenRid = len(rid)
 		h      = recordHeap{
@@ -71,7 +74,13 @@ func (ms *mergeSkip) Merge(rid Rid, threshold int, collector Collector) error {
 
 	heap.Init(&h)
 
-	for h.Len() > 0 {
+	for steps := 0; h.Len() > 0; steps++ {
+		if steps%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
 		poppedItems = 0
 		t := h.top()
 
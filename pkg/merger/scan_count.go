@@ -1,5 +1,7 @@
 package merger
 
+import "context"
+
 // ScanCount scan the N inverted lists one by one.
 // For each string id on each list, we increment the count
 // corresponding to the string by 1. We report the string ids that
@@ -11,11 +13,11 @@ func ScanCount() ListMerger {
 type scanCount struct{}
 
 // Merge returns list of candidates, that appears at least `threshold` times.
-func (lm *scanCount) Merge(rid Rid, threshold int, collector Collector) error {
+func (lm *scanCount) Merge(ctx context.Context, rid Rid, threshold int, collector Collector) error {
 	size := len(rid)
 	candidates := make([]MergeCandidate, 0, size)
 	tmp := make([]MergeCandidate, 0, size)
-	j, endMergeCandidate := 0, 0
+	j, endMergeCandidate, steps := 0, 0, 0
 
 	for _, list := range rid {
 		isValid := true
@@ -32,7 +34,13 @@ func (lm *scanCount) Merge(rid Rid, threshold int, collector Collector) error {
 		tmp = tmp[:0]
 		j, endMergeCandidate = 0, len(candidates)
 
-		for j < endMergeCandidate || isValid {
+		for ; j < endMergeCandidate || isValid; steps++ {
+			if steps%contextCheckInterval == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+
 			if j >= endMergeCandidate || (isValid && candidates[j].Position() > current) {
 				tmp = append(tmp, NewMergeCandidate(current, 1))
 
@@ -24,3 +24,7 @@ func (m *cosine) Threshold(alpha float64, sizeA, sizeB int) int {
 func (m *cosine) Distance(inter, sizeA, sizeB int) float64 {
 	return 1 - float64(inter)/math.Sqrt(float64(sizeA*sizeB))
 }
+
+func (m *cosine) String() string {
+	return "Cosine"
+}
@@ -24,3 +24,7 @@ func (m *dice) Threshold(alpha float64, sizeA, sizeB int) int {
 func (m *dice) Distance(inter, sizeA, sizeB int) float64 {
 	return 1 - float64(2*inter)/float64(sizeA+sizeB)
 }
+
+func (m *dice) String() string {
+	return "Dice"
+}
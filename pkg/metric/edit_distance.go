@@ -0,0 +1,182 @@
+package metric
+
+import (
+	"fmt"
+	"math"
+)
+
+// EditDistance is a distance between two strings, that is defined as the minimal
+// cost of the edit operations that turn one string into the other one
+type EditDistance interface {
+	// Distance returns the distance between a and b. If the distance exceeds
+	// the bound, the computation stops and a value greater than the bound is returned
+	Distance(a, b string, bound float64) float64
+	// MaxDistance returns the upper bound of the distance between a and b
+	MaxDistance(a, b string) float64
+}
+
+// EditCosts holds the costs of the edit operations
+type EditCosts struct {
+	Insert     float64
+	Delete     float64
+	Substitute float64
+	// Transpose is a cost of swapping two adjacent characters, it is used only by Damerau-Levenshtein distance
+	Transpose float64
+}
+
+// DefaultEditCosts gives the same unit cost to each edit operation
+var DefaultEditCosts = EditCosts{
+	Insert:     1,
+	Delete:     1,
+	Substitute: 1,
+	Transpose:  1,
+}
+
+// Validate checks that the costs are positive numbers
+func (c EditCosts) Validate() error {
+	for _, cost := range []float64{c.Insert, c.Delete, c.Substitute, c.Transpose} {
+		if cost <= 0 || math.IsInf(cost, 0) || math.IsNaN(cost) {
+			return fmt.Errorf("edit operation cost should be a positive number, got %v", cost)
+		}
+	}
+
+	return nil
+}
+
+// LevenshteinDistance returns Levenshtein distance with the given costs of insertion, deletion and substitution
+func LevenshteinDistance(costs EditCosts) EditDistance {
+	return &editDistance{costs: costs}
+}
+
+// DamerauLevenshteinDistance returns Damerau-Levenshtein distance, which also treats a transposition of two
+// adjacent characters as a single operation. The optimal string alignment variant is used,
+// so a substring can't be edited more than once
+func DamerauLevenshteinDistance(costs EditCosts) EditDistance {
+	return &editDistance{costs: costs, transpositions: true}
+}
+
+// PrefixDistance returns the given edit distance between a and the closest prefix of b, so it tells
+// how many edits are needed to make a a prefix of b, i.e. it is 1 for "mercd" and "mercedes"
+func PrefixDistance(distance EditDistance) EditDistance {
+	if d, ok := distance.(*editDistance); ok {
+		return &editDistance{costs: d.costs, transpositions: d.transpositions, prefix: true}
+	}
+
+	return &prefixDistance{distance: distance}
+}
+
+// GetEditDistance returns the edit distance with the given name
+func GetEditDistance(name string, costs EditCosts) (EditDistance, error) {
+	if err := costs.Validate(); err != nil {
+		return nil, err
+	}
+
+	switch name {
+	case "levenshtein":
+		return LevenshteinDistance(costs), nil
+	case "damerau":
+		return DamerauLevenshteinDistance(costs), nil
+	default:
+		return nil, fmt.Errorf("edit distance %s is not supported", name)
+	}
+}
+
+// editDistance implements the EditDistance interface with the Wagner-Fischer algorithm
+type editDistance struct {
+	costs          EditCosts
+	transpositions bool
+	// prefix tells that the distance to the closest prefix of the second string is computed
+	prefix bool
+}
+
+// Distance returns the distance between a and b. If the distance exceeds
+// the bound, the computation stops and a value greater than the bound is returned
+func (d *editDistance) Distance(a, b string, bound float64) float64 {
+	s, t := []rune(a), []rune(b)
+	n := len(t)
+
+	// rows i-2, i-1 and i of the distance matrix
+	beforePrev, prev, current := make([]float64, n+1), make([]float64, n+1), make([]float64, n+1)
+	prevMin := 0.0
+
+	for j := 1; j <= n; j++ {
+		prev[j] = prev[j-1] + d.costs.Insert
+	}
+
+	for i := 1; i <= len(s); i++ {
+		current[0] = prev[0] + d.costs.Delete
+		currentMin := current[0]
+
+		for j := 1; j <= n; j++ {
+			substitution := prev[j-1]
+
+			if s[i-1] != t[j-1] {
+				substitution += d.costs.Substitute
+			}
+
+			value := math.Min(substitution, math.Min(prev[j]+d.costs.Delete, current[j-1]+d.costs.Insert))
+
+			if d.transpositions && i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				value = math.Min(value, beforePrev[j-2]+d.costs.Transpose)
+			}
+
+			current[j] = value
+			currentMin = math.Min(currentMin, value)
+		}
+
+		// the next rows are built from the last two ones, so their values can't be less
+		if currentMin > bound && prevMin > bound {
+			return math.Min(currentMin, prevMin)
+		}
+
+		prevMin = currentMin
+		beforePrev, prev, current = prev, current, beforePrev
+	}
+
+	if !d.prefix {
+		return prev[n]
+	}
+
+	// the last row holds the distances to each prefix of b
+	distance := prev[0]
+
+	for _, value := range prev[1:] {
+		distance = math.Min(distance, value)
+	}
+
+	return distance
+}
+
+// MaxDistance returns the upper bound of the distance between a and b,
+// which is the cost of deleting each character of a and inserting each character of b
+func (d *editDistance) MaxDistance(a, b string) float64 {
+	if d.prefix {
+		return float64(len([]rune(a))) * d.costs.Delete
+	}
+
+	return float64(len([]rune(a)))*d.costs.Delete + float64(len([]rune(b)))*d.costs.Insert
+}
+
+// prefixDistance computes the distance to the closest prefix with any edit distance
+// by trying each prefix, it is used for the edit distances implemented outside of the package
+type prefixDistance struct {
+	distance EditDistance
+}
+
+// Distance returns the distance between a and the closest prefix of b. If the distance exceeds
+// the bound, a value greater than the bound is returned
+func (d *prefixDistance) Distance(a, b string, bound float64) float64 {
+	runes := []rune(b)
+	distance := math.Inf(1)
+
+	for i := 0; i <= len(runes); i++ {
+		distance = math.Min(distance, d.distance.Distance(a, string(runes[:i]), math.Min(bound, distance)))
+	}
+
+	return distance
+}
+
+// MaxDistance returns the upper bound of the distance between a and the closest prefix of b
+func (d *prefixDistance) MaxDistance(a, b string) float64 {
+	return d.distance.MaxDistance(a, "")
+}
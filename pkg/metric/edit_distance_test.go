@@ -0,0 +1,78 @@
+package metric
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	levenshtein := LevenshteinDistance(DefaultEditCosts)
+	damerau := DamerauLevenshteinDistance(DefaultEditCosts)
+	weighted := LevenshteinDistance(EditCosts{Insert: 1, Delete: 2, Substitute: 0.5, Transpose: 1})
+
+	testCases := []struct {
+		distance EditDistance
+		a, b     string
+		expected float64
+	}{
+		{levenshtein, "", "", 0},
+		{levenshtein, "", "bmw", 3},
+		{levenshtein, "kitten", "sitting", 3},
+		{levenshtein, "bmw", "bwm", 2},
+		{damerau, "bmw", "bwm", 1},
+		{damerau, "ca", "abc", 3},
+		{damerau, "мерседес", "мреседес", 1},
+		{weighted, "audi", "aud", 2},
+		{weighted, "aud", "audi", 1},
+		{weighted, "audi", "audo", 0.5},
+	}
+
+	for _, testCase := range testCases {
+		actual := testCase.distance.Distance(testCase.a, testCase.b, math.Inf(1))
+		assert.Equal(t, testCase.expected, actual, "%s -> %s", testCase.a, testCase.b)
+	}
+}
+
+func TestBoundedEditDistance(t *testing.T) {
+	levenshtein := LevenshteinDistance(DefaultEditCosts)
+
+	assert.Equal(t, 1.0, levenshtein.Distance("mercedes", "mersedes", 1))
+	assert.Greater(t, levenshtein.Distance("mercedes", "volkswagen", 2), 2.0)
+}
+
+func TestPrefixDistance(t *testing.T) {
+	damerau := PrefixDistance(DamerauLevenshteinDistance(DefaultEditCosts))
+	generic := &prefixDistance{distance: LevenshteinDistance(DefaultEditCosts)}
+
+	testCases := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"", "mercedes", 0},
+		{"merc", "mercedes", 0},
+		{"mercde", "mercedes", 1},
+		{"mrec", "mercedes", 1},
+		{"mersedes", "mercedes", 1},
+		{"audi", "bmw", 4},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, damerau.Distance(testCase.a, testCase.b, math.Inf(1)), "%s -> %s", testCase.a, testCase.b)
+	}
+
+	assert.Equal(t, 1.0, generic.Distance("mercde", "mercedes", math.Inf(1)))
+	assert.Equal(t, 4.0, damerau.MaxDistance("audi", "bmw"))
+}
+
+func TestGetEditDistance(t *testing.T) {
+	_, err := GetEditDistance("levenshtein", DefaultEditCosts)
+	assert.NoError(t, err)
+
+	_, err = GetEditDistance("hamming", DefaultEditCosts)
+	assert.Error(t, err)
+
+	_, err = GetEditDistance("damerau", EditCosts{Insert: 1, Delete: 1, Substitute: 0, Transpose: 1})
+	assert.Error(t, err)
+}
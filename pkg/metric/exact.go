@@ -22,3 +22,7 @@ func (m *exact) Threshold(alpha float64, sizeA, sizeB int) int {
 func (m *exact) Distance(inter, sizeA, sizeB int) float64 {
 	return 0
 }
+
+func (m *exact) String() string {
+	return "Exact"
+}
@@ -0,0 +1,89 @@
+package metric
+
+import "math"
+
+// WeightedMetric is a Metric, that takes into account the weights of the set elements, i.e. the IDF of the n-grams,
+// so the common n-grams like "$ca" matter less than the rare ones. The index is searched with the unweighted
+// Metric, then the found candidates are re-scored with WeightedDistance
+type WeightedMetric interface {
+	Metric
+	// WeightedDistance returns the distance between 2 sets given the weights of their shared elements
+	// and the weights of the elements of each set
+	WeightedDistance(shared, a, b []float64) float64
+}
+
+// IDF returns the smoothed inverse document frequency of an element, that is contained in df of n documents
+func IDF(df, n int) float64 {
+	return math.Log(float64(n+1)/float64(df+1)) + 1
+}
+
+// IDFJaccardMetric returns a WeightedMetric, that represents Jaccard Metric of the IDF weighted sets
+func IDFJaccardMetric() Metric {
+	return &idfJaccard{}
+}
+
+type idfJaccard struct {
+	jaccard
+}
+
+// 1 - w(intersection) / w(union) = 1 - w(intersection) / (w(A) + w(B) - w(intersection))
+func (m *idfJaccard) WeightedDistance(shared, a, b []float64) float64 {
+	inter := sum(shared)
+	union := sum(a) + sum(b) - inter
+
+	if union <= 0 {
+		return 1
+	}
+
+	return 1 - inter/union
+}
+
+func (m *idfJaccard) String() string {
+	return "IDFJaccard"
+}
+
+// IDFCosineMetric returns a WeightedMetric, that represents Cosine Metric of the IDF weighted sets
+func IDFCosineMetric() Metric {
+	return &idfCosine{}
+}
+
+type idfCosine struct {
+	cosine
+}
+
+// 1 - (A * B) / (|A| * |B|), where each set is a vector of the weights of its elements
+func (m *idfCosine) WeightedDistance(shared, a, b []float64) float64 {
+	norm := math.Sqrt(sumOfSquares(a) * sumOfSquares(b))
+
+	if norm == 0 {
+		return 1
+	}
+
+	return 1 - sumOfSquares(shared)/norm
+}
+
+func (m *idfCosine) String() string {
+	return "IDFCosine"
+}
+
+// sum returns the sum of the given weights
+func sum(weights []float64) float64 {
+	total := 0.0
+
+	for _, w := range weights {
+		total += w
+	}
+
+	return total
+}
+
+// sumOfSquares returns the sum of the squares of the given weights
+func sumOfSquares(weights []float64) float64 {
+	total := 0.0
+
+	for _, w := range weights {
+		total += w * w
+	}
+
+	return total
+}
@@ -0,0 +1,28 @@
+package metric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeightedDistance(t *testing.T) {
+	jaccard := IDFJaccardMetric().(WeightedMetric)
+	cosine := IDFCosineMetric().(WeightedMetric)
+
+	// equal weights give the unweighted distances
+	ones := []float64{1, 1, 1, 1}
+	assert.InDelta(t, JaccardMetric().Distance(2, 4, 4), jaccard.WeightedDistance(ones[:2], ones, ones), 1e-9)
+	assert.InDelta(t, CosineMetric().Distance(2, 4, 4), cosine.WeightedDistance(ones[:2], ones, ones), 1e-9)
+
+	// a shared rare element weighs more than a shared common one
+	rare := jaccard.WeightedDistance([]float64{3}, []float64{3, 1}, []float64{3, 1})
+	common := jaccard.WeightedDistance([]float64{1}, []float64{3, 1}, []float64{1, 3})
+	assert.Less(t, rare, common)
+
+	assert.Equal(t, 1.0, jaccard.WeightedDistance(nil, nil, nil))
+	assert.Equal(t, 1.0, cosine.WeightedDistance(nil, nil, nil))
+
+	assert.Equal(t, 1.0, IDF(10, 10))
+	assert.Greater(t, IDF(1, 10), IDF(5, 10))
+}
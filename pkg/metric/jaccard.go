@@ -25,3 +25,7 @@ func (m *jaccard) Threshold(alpha float64, sizeA, sizeB int) int {
 func (m *jaccard) Distance(inter, sizeA, sizeB int) float64 {
 	return 1 - float64(inter)/float64(sizeA+sizeB-inter)
 }
+
+func (m *jaccard) String() string {
+	return "Jaccard"
+}
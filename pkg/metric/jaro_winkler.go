@@ -0,0 +1,110 @@
+package metric
+
+import "github.com/suggest-go/suggest/pkg/utils"
+
+// jaroWinklerScalingFactor is how much the score is adjusted upwards for having a common prefix
+const jaroWinklerScalingFactor = 0.1
+
+// jaroWinklerMaxPrefix is the maximal length of the common prefix, that adjusts the score
+const jaroWinklerMaxPrefix = 4
+
+// StringMetric is a Metric, that compares the strings themselves. The index is searched with
+// the n-gram set Metric, then the found candidates are re-scored with Similarity
+type StringMetric interface {
+	Metric
+	// Similarity returns the similarity of a and b in [0, 1]
+	Similarity(a, b string) float64
+}
+
+// JaroWinklerMetric returns a StringMetric, that re-scores the candidates found by Cosine Metric
+// with Jaro-Winkler similarity, which favours the strings with a common prefix
+func JaroWinklerMetric() Metric {
+	return &jaroWinkler{}
+}
+
+type jaroWinkler struct {
+	cosine
+}
+
+func (m *jaroWinkler) Similarity(a, b string) float64 {
+	return JaroWinklerSimilarity(a, b)
+}
+
+func (m *jaroWinkler) String() string {
+	return "JaroWinkler"
+}
+
+// JaroWinklerSimilarity returns Jaro similarity of a and b adjusted upwards for their common prefix
+// of at most 4 characters
+func JaroWinklerSimilarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	similarity := jaroSimilarity(ra, rb)
+	prefix := 0
+
+	for prefix < len(ra) && prefix < len(rb) && prefix < jaroWinklerMaxPrefix && ra[prefix] == rb[prefix] {
+		prefix++
+	}
+
+	return similarity + float64(prefix)*jaroWinklerScalingFactor*(1-similarity)
+}
+
+// jaroSimilarity returns Jaro similarity of a and b. The characters match if they are equal
+// and are not farther than half of the longest string from each other
+func jaroSimilarity(a, b []rune) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	window := utils.Max(len(a), len(b))/2 - 1
+
+	if window < 0 {
+		window = 0
+	}
+
+	matchedA := make([]bool, len(a))
+	matchedB := make([]bool, len(b))
+	matches := 0
+
+	for i := range a {
+		from, to := utils.Max(0, i-window), utils.Min(len(b)-1, i+window)
+
+		for j := from; j <= to; j++ {
+			if !matchedB[j] && a[i] == b[j] {
+				matchedA[i], matchedB[j] = true, true
+				matches++
+
+				break
+			}
+		}
+	}
+
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions, j := 0, 0
+
+	for i := range a {
+		if !matchedA[i] {
+			continue
+		}
+
+		for !matchedB[j] {
+			j++
+		}
+
+		if a[i] != b[j] {
+			transpositions++
+		}
+
+		j++
+	}
+
+	m := float64(matches)
+
+	return (m/float64(len(a)) + m/float64(len(b)) + (m-float64(transpositions)/2)/m) / 3
+}
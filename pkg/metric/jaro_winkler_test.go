@@ -0,0 +1,30 @@
+package metric
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJaroWinklerSimilarity(t *testing.T) {
+	testCases := []struct {
+		a, b     string
+		expected float64
+	}{
+		{"", "", 1},
+		{"bmw", "", 0},
+		{"martha", "marhta", 0.961},
+		{"dwayne", "duane", 0.84},
+		{"dixon", "dicksonx", 0.813},
+		{"мерседес", "мерседес", 1},
+		{"abc", "xyz", 0},
+	}
+
+	for _, testCase := range testCases {
+		assert.InDelta(t, testCase.expected, JaroWinklerSimilarity(testCase.a, testCase.b), 0.001, testCase.a+" "+testCase.b)
+	}
+
+	m, ok := JaroWinklerMetric().(StringMetric)
+	assert.True(t, ok)
+	assert.Equal(t, JaroWinklerSimilarity("martha", "marhta"), m.Similarity("martha", "marhta"))
+}
@@ -1,6 +1,8 @@
 // Package metric holds different metrics for sets similarity compare
 package metric
 
+import "fmt"
+
 // Metric defined here, is not pure mathematics metric definition as distance between each pair of elements of a set.
 // Here we can also ask metric to give as minimum intersection between A and B for given alpha,
 // min/max candidate cardinality
@@ -14,3 +16,27 @@ type Metric interface {
 	// Distance calculate distance between 2 sets
 	Distance(inter, sizeA, sizeB int) float64
 }
+
+// GetMetric returns the metric with the given name
+func GetMetric(name string) (Metric, error) {
+	switch name {
+	case "Jaccard":
+		return JaccardMetric(), nil
+	case "Cosine":
+		return CosineMetric(), nil
+	case "Dice":
+		return DiceMetric(), nil
+	case "Exact":
+		return ExactMetric(), nil
+	case "Overlap":
+		return OverlapMetric(), nil
+	case "IDFJaccard":
+		return IDFJaccardMetric(), nil
+	case "IDFCosine":
+		return IDFCosineMetric(), nil
+	case "JaroWinkler":
+		return JaroWinklerMetric(), nil
+	default:
+		return nil, fmt.Errorf("metric %s is not supported", name)
+	}
+}
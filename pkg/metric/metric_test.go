@@ -0,0 +1,19 @@
+package metric
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMetric(t *testing.T) {
+	for _, name := range []string{"Jaccard", "Cosine", "Dice", "Exact", "Overlap", "IDFJaccard", "IDFCosine", "JaroWinkler"} {
+		m, err := GetMetric(name)
+		assert.NoError(t, err)
+		assert.Equal(t, name, fmt.Sprint(m))
+	}
+
+	_, err := GetMetric("Levenshtein")
+	assert.Error(t, err)
+}
@@ -24,3 +24,7 @@ func (m *overlap) Threshold(alpha float64, sizeA, sizeB int) int {
 func (m *overlap) Distance(inter, sizeA, sizeB int) float64 {
 	return 1 - float64(inter)/(math.Min(float64(sizeA), float64(sizeB)))
 }
+
+func (m *overlap) String() string {
+	return "Overlap"
+}
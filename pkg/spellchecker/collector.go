@@ -3,15 +3,14 @@ package spellchecker
 import (
 	"errors"
 
-	"github.com/suggest-go/suggest/pkg/lm"
 	"github.com/suggest-go/suggest/pkg/merger"
 	"github.com/suggest-go/suggest/pkg/suggest"
 )
 
-// lmCollector implements Collector interface
+// lmCollector implements Collector interface, the candidates are ranked by the language model
 type lmCollector struct {
 	topKQueue suggest.TopKQueue
-	scorer    suggest.Scorer
+	lmScorer  suggest.Scorer
 }
 
 // newCollectorManager creates a new instance of lm CollectorManger.
@@ -34,7 +33,7 @@ type lmCollectorManager struct {
 func (l *lmCollectorManager) Create() suggest.Collector {
 	return &lmCollector{
 		topKQueue: l.queueFactory(),
-		scorer:    l.scorer,
+		lmScorer:  l.scorer,
 	}
 }
 
@@ -59,28 +58,14 @@ func (l *lmCollectorManager) GetCandidates() []suggest.Candidate {
 
 // Collect collects the given candidate
 func (c *lmCollector) Collect(item merger.MergeCandidate) error {
-	doc := item.Position()
-
-	if c.scorer == nil {
-		if c.topKQueue.IsFull() {
-			return merger.ErrCollectionTerminated
-		}
-
-		c.topKQueue.Add(doc, lm.UnknownWordScore)
-
-		return nil
-	}
-
-	score := c.scorer.Score(item)
-	c.topKQueue.Add(doc, score)
+	c.topKQueue.Add(item.Position(), c.lmScorer.Score(item))
 
 	return nil
 }
 
-// SetScorer sets the scorer for calculations
-func (c *lmCollector) SetScorer(scorer suggest.Scorer) {
-	c.scorer = scorer
-}
+// SetScorer ignores the scorer of the search, as the candidates are ranked by the language
+// model scorer of the collector manager, which is the dummy one for an unknown context
+func (c *lmCollector) SetScorer(suggest.Scorer) {}
 
 // GetCandidates returns `top k items`
 func (c *lmCollector) GetCandidates() []suggest.Candidate {
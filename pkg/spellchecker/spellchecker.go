@@ -4,6 +4,7 @@ package spellchecker
 // TODO add tests!!
 
 import (
+	"context"
 	"sort"
 
 	"github.com/suggest-go/suggest/pkg/analysis"
@@ -37,7 +38,7 @@ func New(
 }
 
 // Predict predicts the next word of the sentence
-func (s *SpellChecker) Predict(query string, topK int, similarity float64) ([]string, error) {
+func (s *SpellChecker) Predict(ctx context.Context, query string, topK int, similarity float64) ([]string, error) {
 	tokens := s.tokenizer.Tokenize(query)
 
 	if len(tokens) == 0 {
@@ -55,7 +56,7 @@ func (s *SpellChecker) Predict(query string, topK int, similarity float64) ([]st
 		return suggest.NewTopKQueue(topK)
 	}
 
-	candidates, err := s.index.Autocomplete(word, func() suggest.CollectorManager {
+	candidates, err := s.index.Autocomplete(ctx, word, func() suggest.CollectorManager {
 		return newCollectorManager(newScorer(scorerNext), queueFactory)
 	})
 
@@ -65,6 +66,7 @@ func (s *SpellChecker) Predict(query string, topK int, similarity float64) ([]st
 
 	if len(candidates) < topK {
 		fuzzyCandidates, err := s.index.Suggest(
+			ctx,
 			word,
 			similarity,
 			metric.CosineMetric(),
@@ -7,4 +7,11 @@ type Directory interface {
 	CreateOutput(name string) (Output, error)
 	// OpenInput returns a reader for the given name
 	OpenInput(name string) (Input, error)
+	// Exists tells if there is a file with the given name in the directory
+	Exists(name string) bool
+	// Rename atomically renames the file with the name from to the name to,
+	// replacing the destination file if it already exists
+	Rename(from, to string) error
+	// Remove removes the file with the given name from the directory
+	Remove(name string) error
 }
@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"fmt"
 	"os"
-	"runtime"
 
 	"github.com/suggest-go/suggest/pkg/utils"
 )
@@ -65,11 +64,52 @@ func (fs *fsDirectory) OpenInput(name string) (Input, error) {
 		return nil, fmt.Errorf("Failed to fetch content: %w", err)
 	}
 
-	input := NewBytesInput(data)
+	return &mmapInput{
+		Input: NewBytesInput(data),
+		file:  file,
+	}, nil
+}
+
+// Exists tells if there is a file with the given name in the directory
+func (fs *fsDirectory) Exists(name string) bool {
+	_, err := os.Stat(fs.path + "/" + name)
+
+	return err == nil
+}
+
+// Rename atomically renames the file with the name from to the name to,
+// replacing the destination file if it already exists
+func (fs *fsDirectory) Rename(from, to string) error {
+	if err := os.Rename(fs.path+"/"+from, fs.path+"/"+to); err != nil {
+		return fmt.Errorf("Failed to rename %s: %w", from, err)
+	}
+
+	return nil
+}
+
+// Remove removes the file with the given name from the directory
+func (fs *fsDirectory) Remove(name string) error {
+	if err := os.Remove(fs.path + "/" + name); err != nil {
+		return fmt.Errorf("Failed to remove %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// mmapInput is an Input over a memory mapped file, the file is unmapped on Close
+// or, if Close has never been called, when the input becomes unreachable
+type mmapInput struct {
+	Input
+	file *utils.MMapReader
+}
 
-	runtime.SetFinalizer(input, func(d interface{}) {
-		file.Close()
-	})
+// Close unmaps the underlying file. The input and all its slices
+// must not be used after this call
+func (m *mmapInput) Close() error {
+	return m.file.Close()
+}
 
-	return input, nil
+// Data returns the underlying content as byte slice
+func (m *mmapInput) Data() []byte {
+	return m.Input.(SliceAccessible).Data()
 }
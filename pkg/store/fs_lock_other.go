@@ -0,0 +1,45 @@
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package store
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Lock obtains an exclusive lock with the given name by creating the lock file.
+// Unlike flock, it fails instead of waiting if the lock is held, and the lock file
+// of a crashed holder has to be removed manually
+func (fs *fsDirectory) Lock(name string) (io.Closer, error) {
+	path := fs.path + "/" + name
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+
+	if os.IsExist(err) {
+		return nil, fmt.Errorf("Lock %s is held by another writer, remove %s if its holder has crashed", name, path)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create lock file: %w", err)
+	}
+
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("Failed to create lock file: %w", err)
+	}
+
+	return &fsLock{path: path}, nil
+}
+
+// fsLock is a lock held by the existence of the lock file
+type fsLock struct {
+	path string
+}
+
+// Close releases the lock
+func (l *fsLock) Close() error {
+	if err := os.Remove(l.path); err != nil {
+		return fmt.Errorf("Failed to release lock: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package store
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"syscall"
+)
+
+// Lock obtains an exclusive lock with the given name by flock on the lock file.
+// The lock is released by the OS if the holder process dies
+func (fs *fsDirectory) Lock(name string) (io.Closer, error) {
+	file, err := os.OpenFile(fs.path+"/"+name, os.O_CREATE|os.O_RDWR, 0644)
+
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open lock file: %w", err)
+	}
+
+	if err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("Failed to obtain lock %s: %w", name, err)
+	}
+
+	return &fsLock{file: file}, nil
+}
+
+// fsLock is a lock held on the lock file
+type fsLock struct {
+	file *os.File
+}
+
+// Close releases the lock
+func (l *fsLock) Close() error {
+	if err := syscall.Flock(int(l.file.Fd()), syscall.LOCK_UN); err != nil {
+		l.file.Close()
+		return fmt.Errorf("Failed to release lock: %w", err)
+	}
+
+	return l.file.Close()
+}
@@ -0,0 +1,11 @@
+package store
+
+import "io"
+
+// Locker is implemented by the directories, which are able to guard their files
+// against concurrent writers, including the writers of other processes
+type Locker interface {
+	// Lock obtains an exclusive lock with the given name, it blocks until
+	// the lock is released by its current holder. The lock is released on Close
+	Lock(name string) (io.Closer, error)
+}
@@ -3,11 +3,13 @@ package store
 import (
 	"bytes"
 	"fmt"
+	"sync"
 )
 
 // ramDirectory is a implementation that stores index
 // files in RAM
 type ramDirectory struct {
+	lock  sync.RWMutex
 	files map[string]*bytes.Buffer
 }
 
@@ -20,15 +22,20 @@ func NewRAMDirectory() Directory {
 
 // CreateOutput creates a new writer in the given directory with the given name
 func (rd *ramDirectory) CreateOutput(name string) (Output, error) {
-	if _, ok := rd.files[name]; !ok {
-		rd.files[name] = &bytes.Buffer{}
-	}
+	rd.lock.Lock()
+	defer rd.lock.Unlock()
+
+	buf := &bytes.Buffer{}
+	rd.files[name] = buf
 
-	return NewBytesOutput(rd.files[name]), nil
+	return NewBytesOutput(buf), nil
 }
 
 // OpenInput returns a reader for the given name
 func (rd *ramDirectory) OpenInput(name string) (Input, error) {
+	rd.lock.RLock()
+	defer rd.lock.RUnlock()
+
 	if _, ok := rd.files[name]; !ok {
 		return nil, fmt.Errorf("Failed to open input reader: there is no such input with the name %s", name)
 	}
@@ -37,3 +44,45 @@ func (rd *ramDirectory) OpenInput(name string) (Input, error) {
 
 	return NewBytesInput(data), nil
 }
+
+// Exists tells if there is a file with the given name in the directory
+func (rd *ramDirectory) Exists(name string) bool {
+	rd.lock.RLock()
+	defer rd.lock.RUnlock()
+
+	_, ok := rd.files[name]
+
+	return ok
+}
+
+// Rename atomically renames the file with the name from to the name to,
+// replacing the destination file if it already exists
+func (rd *ramDirectory) Rename(from, to string) error {
+	rd.lock.Lock()
+	defer rd.lock.Unlock()
+
+	buf, ok := rd.files[from]
+
+	if !ok {
+		return fmt.Errorf("Failed to rename: there is no such file with the name %s", from)
+	}
+
+	delete(rd.files, from)
+	rd.files[to] = buf
+
+	return nil
+}
+
+// Remove removes the file with the given name from the directory
+func (rd *ramDirectory) Remove(name string) error {
+	rd.lock.Lock()
+	defer rd.lock.Unlock()
+
+	if _, ok := rd.files[name]; !ok {
+		return fmt.Errorf("Failed to remove: there is no such file with the name %s", name)
+	}
+
+	delete(rd.files, name)
+
+	return nil
+}
@@ -0,0 +1,256 @@
+package suggest
+
+import (
+	"encoding/gob"
+	"fmt"
+	"strings"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+// Attributes holds the posting lists of the filterable document attributes,
+// i.e. for each attribute value the set of documents that have the value
+type Attributes map[string]map[string]*roaring.Bitmap
+
+// NewAttributes creates a new instance of Attributes for the attribute values of the given documents
+func NewAttributes(docs []Document) Attributes {
+	attributes := Attributes{}
+
+	for _, doc := range docs {
+		attributes.add(doc.Key, doc.Attributes)
+	}
+
+	return attributes
+}
+
+// Get returns the documents that have the given value of the attribute
+func (a Attributes) Get(attribute, value string) *roaring.Bitmap {
+	if list, ok := a[attribute][value]; ok {
+		return list
+	}
+
+	return roaring.New()
+}
+
+// add adds the given attribute values of the document with the key
+func (a Attributes) add(key dictionary.Key, values map[string][]string) {
+	for attribute, list := range values {
+		if _, ok := a[attribute]; !ok {
+			a[attribute] = make(map[string]*roaring.Bitmap)
+		}
+
+		for _, value := range list {
+			if _, ok := a[attribute][value]; !ok {
+				a[attribute][value] = roaring.New()
+			}
+
+			a[attribute][value].Add(key)
+		}
+	}
+}
+
+// merge adds the posting lists of the given attributes except the excluded documents
+func (a Attributes) merge(other Attributes, excluded *roaring.Bitmap) {
+	for attribute, values := range other {
+		if _, ok := a[attribute]; !ok {
+			a[attribute] = make(map[string]*roaring.Bitmap)
+		}
+
+		for value, list := range values {
+			list = roaring.AndNot(list, excluded)
+
+			if current, ok := a[attribute][value]; ok {
+				current.Or(list)
+			} else {
+				a[attribute][value] = list
+			}
+		}
+	}
+}
+
+// renumber assigns the attribute values of the live documents to their renumbered keys,
+// the new key of a document is its rank in the live set
+func (a Attributes) renumber(live *roaring.Bitmap) Attributes {
+	renumbered := Attributes{}
+
+	for attribute, values := range a {
+		renumbered[attribute] = make(map[string]*roaring.Bitmap)
+
+		for value, list := range values {
+			keys := roaring.New()
+			it := roaring.And(list, live).Iterator()
+
+			for it.HasNext() {
+				keys.Add(uint32(live.Rank(it.Next()) - 1))
+			}
+
+			if !keys.IsEmpty() {
+				renumbered[attribute][value] = keys
+			}
+		}
+	}
+
+	return renumbered
+}
+
+// OpenAttributes reads the attributes of all segments of the on-disc index with the given description
+func OpenAttributes(directory store.Directory, description IndexDescription) (Attributes, error) {
+	infos, err := index.ReadSegmentInfos(directory, description.Name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read segments: %w", err)
+	}
+
+	attributes := Attributes{}
+
+	for _, info := range infos.Segments {
+		deletions, err := index.ReadDeletions(directory, info)
+
+		if err != nil {
+			return nil, err
+		}
+
+		segment, err := readAttributes(directory, attributesFileName(info.Name))
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read attributes of segment %s: %w", info.Name, err)
+		}
+
+		attributes.merge(segment, deletions)
+	}
+
+	return attributes, nil
+}
+
+// readAttributes reads the attributes file with the given name.
+// A missing file means that the documents have no attributes
+func readAttributes(directory store.Directory, fileName string) (Attributes, error) {
+	attributes := Attributes{}
+
+	if !directory.Exists(fileName) {
+		return attributes, nil
+	}
+
+	input, err := directory.OpenInput(fileName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open attributes file: %w", err)
+	}
+
+	if err := gob.NewDecoder(input).Decode(&attributes); err != nil {
+		return nil, fmt.Errorf("failed to decode attributes: %w", err)
+	}
+
+	return attributes, input.Close()
+}
+
+// writeAttributes persists the given attributes into the file with the given name
+func writeAttributes(directory store.Directory, fileName string, attributes Attributes) error {
+	output, err := directory.CreateOutput(fileName)
+
+	if err != nil {
+		return fmt.Errorf("failed to create attributes file: %w", err)
+	}
+
+	for _, values := range attributes {
+		for _, list := range values {
+			list.RunOptimize()
+		}
+	}
+
+	if err := gob.NewEncoder(output).Encode(attributes); err != nil {
+		return fmt.Errorf("failed to encode attributes: %w", err)
+	}
+
+	if err := output.Close(); err != nil {
+		return fmt.Errorf("failed to close attributes file: %w", err)
+	}
+
+	return nil
+}
+
+// attributesFileName returns the name of the attributes file of the given index segment
+func attributesFileName(segment string) string {
+	return fmt.Sprintf("%s.attr", segment)
+}
+
+// FilterClause restricts a query to the documents, which attribute has one of the values
+type FilterClause struct {
+	Attribute string
+	Values    []string
+}
+
+// Filter is a conjunction of filter clauses
+type Filter []FilterClause
+
+// ParseFilterClause parses a filter clause of the form "attribute=value" or "attribute in (value1, value2)"
+func ParseFilterClause(expr string) (FilterClause, error) {
+	if i := strings.IndexByte(expr, '='); i >= 0 {
+		clause := FilterClause{
+			Attribute: strings.TrimSpace(expr[:i]),
+			Values:    []string{strings.TrimSpace(expr[i+1:])},
+		}
+
+		if clause.Attribute == "" {
+			return FilterClause{}, fmt.Errorf("filter clause %q has no attribute", expr)
+		}
+
+		return clause, nil
+	}
+
+	fields := strings.SplitN(strings.TrimSpace(expr), " ", 2)
+
+	if len(fields) != 2 {
+		return FilterClause{}, fmt.Errorf("filter clause %q is malformed", expr)
+	}
+
+	list := strings.TrimSpace(fields[1])
+
+	if !strings.HasPrefix(strings.ToLower(list), "in") {
+		return FilterClause{}, fmt.Errorf("filter clause %q is malformed", expr)
+	}
+
+	list = strings.TrimSpace(list[2:])
+
+	if !strings.HasPrefix(list, "(") || !strings.HasSuffix(list, ")") {
+		return FilterClause{}, fmt.Errorf("filter clause %q should enclose its values in parentheses", expr)
+	}
+
+	clause := FilterClause{Attribute: fields[0]}
+
+	for _, value := range strings.Split(list[1:len(list)-1], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			clause.Values = append(clause.Values, value)
+		}
+	}
+
+	if len(clause.Values) == 0 {
+		return FilterClause{}, fmt.Errorf("filter clause %q has no values", expr)
+	}
+
+	return clause, nil
+}
+
+// evaluate returns the set of documents, that satisfy each clause of the filter
+func (f Filter) evaluate(attributes Attributes) *roaring.Bitmap {
+	var result *roaring.Bitmap
+
+	for _, clause := range f {
+		allowed := roaring.New()
+
+		for _, value := range clause.Values {
+			allowed.Or(attributes.Get(clause.Attribute, value))
+		}
+
+		if result == nil {
+			result = allowed
+		} else {
+			result.And(allowed)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,144 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+func TestAttributes(t *testing.T) {
+	attributes := NewAttributes([]Document{
+		{Key: 0, Attributes: map[string][]string{"category": {"sedan"}, "brand": {"bmw"}}},
+		{Key: 3, Attributes: map[string][]string{"category": {"suv", "4wd"}, "brand": {"bmw"}}},
+		{Key: 5, Attributes: map[string][]string{"category": {"suv"}, "brand": {"audi"}}},
+	})
+
+	assert.Equal(t, []uint32{3, 5}, attributes.Get("category", "suv").ToArray())
+	assert.True(t, attributes.Get("color", "red").IsEmpty())
+
+	filter := Filter{
+		{Attribute: "category", Values: []string{"suv", "sedan"}},
+		{Attribute: "brand", Values: []string{"bmw"}},
+	}
+	assert.Equal(t, []uint32{0, 3}, filter.evaluate(attributes).ToArray())
+
+	// the delta segment overrides the document 3, the documents are renumbered by their rank in the live set
+	merged := Attributes{}
+	merged.merge(attributes, roaring.BitmapOf(3))
+	merged.merge(NewAttributes([]Document{{Key: 3, Attributes: map[string][]string{"category": {"sedan"}}}}), roaring.New())
+
+	renumbered := merged.renumber(roaring.BitmapOf(3, 5))
+	assert.Equal(t, []uint32{0}, renumbered.Get("category", "sedan").ToArray())
+	assert.Equal(t, []uint32{1}, renumbered.Get("category", "suv").ToArray())
+	assert.Equal(t, []uint32{1}, renumbered.Get("brand", "audi").ToArray())
+	assert.NotContains(t, renumbered["brand"], "bmw")
+}
+
+func TestPersistedAttributes(t *testing.T) {
+	directory := store.NewRAMDirectory()
+	expected := NewAttributes([]Document{
+		{Key: 1, Attributes: map[string][]string{"category": {"suv", "4wd"}}},
+		{Key: 2, Attributes: map[string][]string{"category": {"suv"}}},
+	})
+
+	assert.NoError(t, writeAttributes(directory, attributesFileName("cars"), expected))
+
+	actual, err := readAttributes(directory, attributesFileName("cars"))
+	assert.NoError(t, err)
+	assert.True(t, expected.Get("category", "suv").Equals(actual.Get("category", "suv")))
+	assert.True(t, expected.Get("category", "4wd").Equals(actual.Get("category", "4wd")))
+}
+
+func TestFilteredSearch(t *testing.T) {
+	source := `{"title": "BMW 320", "category": "sedan", "brand": "bmw"}
+{"title": "BMW 520", "category": "sedan", "brand": "bmw"}
+{"title": "BMW 740", "category": "sedan", "brand": "bmw"}
+{"title": "BMW X5", "category": ["suv", "4wd"], "brand": "bmw"}
+{"title": "BMW X6", "category": ["suv", "4wd"], "brand": "bmw"}
+{"title": "AUDI Q5", "category": "suv", "brand": "audi"}
+`
+	directory, description := buildTestIndex(t, source, func(description *IndexDescription) {
+		description.Format = JSONLinesFormat
+		description.Field = "title"
+		description.Attributes = []string{"category", "brand"}
+	})
+
+	suv, err := ParseFilterClause("category=suv")
+	assert.NoError(t, err)
+
+	brands, err := ParseFilterClause("brand in (bmw, audi)")
+	assert.NoError(t, err)
+
+	for _, driver := range []Driver{DiscDriver, RAMDriver} {
+		description.Driver = driver
+		service := NewService()
+		assert.NoError(t, service.AddIndexByDescription(description))
+
+		result, err := service.Autocomplete(context.Background(), description.Name, "BMW", 2, WithFilter(suv, brands))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"BMW X5", "BMW X6"}, resultValues(result))
+
+		config, err := NewSearchConfig("BMW X", 5, metric.CosineMetric(), 0.3, WithFilter(suv))
+		assert.NoError(t, err)
+
+		result, err = service.Suggest(context.Background(), description.Name, config)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, []string{"BMW X5", "BMW X6"}, resultValues(result))
+
+		result, err = service.Autocomplete(context.Background(), description.Name, "BMW", 5, WithFilter(FilterClause{Attribute: "color", Values: []string{"red"}}))
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+	}
+
+	service := NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+	assert.NoError(t, service.UpdateDocuments(description.Name, []Document{
+		{Key: 0, Value: "BMW 320", Attributes: map[string][]string{"category": {"suv"}}},
+	}))
+	assert.NoError(t, service.DeleteDocuments(description.Name, []dictionary.Key{3}))
+
+	result, err := service.Autocomplete(context.Background(), description.Name, "BMW", 5, WithFilter(suv))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BMW 320", "BMW X6"}, resultValues(result))
+
+	assert.NoError(t, Compact(directory, description))
+	assert.NoError(t, service.AddOnDiscIndex(description))
+
+	result, err = service.Autocomplete(context.Background(), description.Name, "BMW", 5, WithFilter(suv))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BMW 320", "BMW X6"}, resultValues(result))
+}
+
+func TestParseFilterClause(t *testing.T) {
+	testCases := []struct {
+		expr     string
+		expected FilterClause
+		fail     bool
+	}{
+		{"category=sedan", FilterClause{Attribute: "category", Values: []string{"sedan"}}, false},
+		{" year = 2020 ", FilterClause{Attribute: "year", Values: []string{"2020"}}, false},
+		{"brand in (bmw, audi)", FilterClause{Attribute: "brand", Values: []string{"bmw", "audi"}}, false},
+		{"brand IN(bmw)", FilterClause{Attribute: "brand", Values: []string{"bmw"}}, false},
+		{"=sedan", FilterClause{}, true},
+		{"brand in ()", FilterClause{}, true},
+		{"brand in bmw", FilterClause{}, true},
+		{"brand", FilterClause{}, true},
+	}
+
+	for _, testCase := range testCases {
+		clause, err := ParseFilterClause(testCase.expr)
+
+		if testCase.fail {
+			assert.Error(t, err, testCase.expr)
+			continue
+		}
+
+		assert.NoError(t, err, testCase.expr)
+		assert.Equal(t, testCase.expected, clause)
+	}
+}
@@ -1,19 +1,101 @@
 package suggest
 
 import (
+	"context"
 	"fmt"
 	"sync"
+	"unicode/utf8"
 
 	"github.com/suggest-go/suggest/pkg/analysis"
 	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/utils"
 	"golang.org/x/sync/errgroup"
 )
 
 // Autocomplete provides autocomplete functionality
 // for candidates search
 type Autocomplete interface {
-	// Autocomplete returns candidates where the query string is a substring of each candidate
-	Autocomplete(query string, factory CollectorManagerFactory) ([]Candidate, error)
+	// Autocomplete returns candidates where the query string is a substring of each candidate.
+	// If the context is done before the search is finished, the candidates collected so far
+	// are returned along with the context error
+	Autocomplete(ctx context.Context, query string, factory CollectorManagerFactory) ([]Candidate, error)
+}
+
+// AutocompleteMatching tells where Autocomplete looks for the query in a candidate
+type AutocompleteMatching string
+
+const (
+	// PrefixMatching matches the query at the beginning of a candidate
+	PrefixMatching AutocompleteMatching = "prefix"
+	// WordBoundaryMatching matches the query at the beginning of any word of a candidate
+	WordBoundaryMatching AutocompleteMatching = "word"
+	// InfixMatching matches the query at any position of a candidate
+	InfixMatching AutocompleteMatching = "infix"
+)
+
+// ParseAutocompleteMatching returns the autocomplete matching with the given name
+func ParseAutocompleteMatching(name string) (AutocompleteMatching, error) {
+	switch matching := AutocompleteMatching(name); matching {
+	case PrefixMatching, WordBoundaryMatching, InfixMatching:
+		return matching, nil
+	default:
+		return "", fmt.Errorf("autocomplete matching %s is not supported", name)
+	}
+}
+
+// WithAutocompleteMatching sets where Autocomplete looks for the query in a candidate, PrefixMatching is used by default.
+// The candidates matched at the beginning come first, so the candidates matched in the middle follow them
+func WithAutocompleteMatching(matching AutocompleteMatching) QueryOption {
+	return func(options *queryOptions) {
+		options.matching = matching
+	}
+}
+
+// infixAutocomplete is an Autocomplete, that can also match the query in the middle of a candidate
+type infixAutocomplete interface {
+	Autocomplete
+	// InfixAutocomplete returns candidates where the query string matches each candidate with the given matching
+	InfixAutocomplete(ctx context.Context, query string, matching AutocompleteMatching, factory CollectorManagerFactory) ([]Candidate, error)
+}
+
+// autocompleteWithMatching returns candidates where the query matches each candidate with the given matching.
+// The prefix matches are looked up first, and the rest matches are looked up with infixFactory and appended
+// to them, so the result can contain twice as many candidates as a collector manager of the factory collects
+func autocompleteWithMatching(
+	ctx context.Context,
+	autocomplete Autocomplete,
+	query string,
+	matching AutocompleteMatching,
+	factory CollectorManagerFactory,
+	infixFactory CollectorManagerFactory,
+) ([]Candidate, error) {
+	candidates, err := autocomplete.Autocomplete(ctx, query, factory)
+
+	if err != nil || matching == "" || matching == PrefixMatching {
+		return candidates, err
+	}
+
+	infix, ok := autocomplete.(infixAutocomplete)
+
+	if !ok {
+		return nil, fmt.Errorf("autocomplete matching %s is not supported by the index", matching)
+	}
+
+	found, err := infix.InfixAutocomplete(ctx, query, matching, infixFactory)
+	keys := make(map[index.Position]struct{}, len(candidates))
+
+	for _, candidate := range candidates {
+		keys[candidate.Key] = struct{}{}
+	}
+
+	for _, candidate := range found {
+		if _, ok := keys[candidate.Key]; !ok {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	return candidates, err
 }
 
 // NewAutocomplete creates a new instance of Autocomplete
@@ -37,41 +119,115 @@ type nGramAutocomplete struct {
 }
 
 // Autocomplete returns candidates where the query string is a prefix of each candidate
-func (n *nGramAutocomplete) Autocomplete(query string, factory CollectorManagerFactory) ([]Candidate, error) {
+func (n *nGramAutocomplete) Autocomplete(ctx context.Context, query string, factory CollectorManagerFactory) ([]Candidate, error) {
+	terms := n.tokenizer.Tokenize(query)
+
+	return n.search(ctx, terms, len(terms), factory)
+}
+
+// FuzzyAutocomplete returns candidates, which prefix differs from the query string by at most maxErrors edits.
+// An edit breaks at most n of the query n-grams, so a candidate should share at least
+// len(terms) - maxErrors * n of them. The candidates are not verified, so some of them can differ more
+func (n *nGramAutocomplete) FuzzyAutocomplete(
+	ctx context.Context,
+	query string,
+	maxErrors int,
+	factory CollectorManagerFactory,
+) ([]Candidate, error) {
 	terms := n.tokenizer.Tokenize(query)
+
+	if len(terms) == 0 {
+		return []Candidate{}, nil
+	}
+
+	nGramSize := utf8.RuneCountInString(terms[0])
+	threshold := utils.Max(1, len(terms)-maxErrors*nGramSize)
+
+	return n.search(ctx, terms, threshold, factory)
+}
+
+// search returns candidates, that share at least threshold of the given terms
+func (n *nGramAutocomplete) search(ctx context.Context, terms []index.Term, threshold int, factory CollectorManagerFactory) ([]Candidate, error) {
 	termsLen := len(terms)
+	lenIndices := n.indices.Size()
+
+	if threshold >= lenIndices {
+		return []Candidate{}, nil
+	}
+
+	// channel that receives the sizes of the candidates in the ascending order,
+	// so the shortest (and the most scored) candidates are processed first
+	sizeCh := make(chan int, lenIndices-threshold)
 	workerPool := errgroup.Group{}
 	collectorManager := factory()
+	bounded, isBounded := collectorManager.(boundedCollectorManager)
 	locker := sync.Mutex{}
 
-	for size := termsLen; size < n.indices.Size(); size++ {
-		invertedIndex := n.indices.Get(size)
+	for i := 0; i < utils.Min(maxSearchQueriesAtOnce, lenIndices-threshold); i++ {
+		workerPool.Go(func() error {
+			for size := range sizeCh {
+				// the search has been interrupted, so the rest sizes are skipped
+				if ctx.Err() != nil {
+					continue
+				}
+
+				invertedIndex := n.indices.Get(size)
 
-		if invertedIndex == nil {
-			continue
-		}
+				if invertedIndex == nil {
+					continue
+				}
 
-		collector := collectorManager.Create()
+				// the candidates of the given size can't score more than the bound, so there is no reason
+				// to look them through, if the collected candidates are better
+				bound := 1.0
 
-		workerPool.Go(func() error {
-			if err := n.searcher.Search(invertedIndex, terms, termsLen, collector); err != nil {
-				return fmt.Errorf("failed to search posting lists: %w", err)
-			}
+				if size > 0 {
+					bound = float64(utils.Min(termsLen, size)) / float64(utils.Max(termsLen, size))
+				}
+
+				if isBounded {
+					locker.Lock()
+					canTake := bounded.CanTakeWithScore(bound)
+					locker.Unlock()
+
+					if !canTake {
+						continue
+					}
+				}
+
+				collector := collectorManager.Create()
+				collector.SetScorer(NewMetricScorer(metric.JaccardMetric(), termsLen, size))
 
-			locker.Lock()
-			defer locker.Unlock()
+				// the candidates found before an interruption are still collected
+				if err := n.searcher.Search(ctx, invertedIndex, terms, threshold, collector); err != nil && ctx.Err() == nil {
+					return fmt.Errorf("failed to search posting lists: %w", err)
+				}
 
-			if err := collectorManager.Collect(collector); err != nil {
-				return err
+				locker.Lock()
+
+				if err := collectorManager.Collect(collector); err != nil {
+					locker.Unlock()
+
+					return err
+				}
+
+				locker.Unlock()
 			}
 
 			return nil
 		})
 	}
 
+	for size := threshold; size < lenIndices; size++ {
+		sizeCh <- size
+	}
+
+	// close input channel for worker pool
+	close(sizeCh)
+
 	if err := workerPool.Wait(); err != nil {
 		return nil, err
 	}
 
-	return collectorManager.GetCandidates(), nil
+	return collectorManager.GetCandidates(), ctx.Err()
 }
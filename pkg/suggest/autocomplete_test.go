@@ -0,0 +1,108 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAutocompleteOptions(t *testing.T) {
+	for _, matching := range []AutocompleteMatching{PrefixMatching, WordBoundaryMatching, InfixMatching} {
+		parsed, err := ParseAutocompleteMatching(string(matching))
+		assert.NoError(t, err)
+		assert.Equal(t, matching, parsed)
+	}
+
+	_, err := ParseAutocompleteMatching("suffix")
+	assert.Error(t, err)
+
+	for _, ranking := range []AutocompleteRanking{FirstFoundRanking, LengthRanking, WeightRanking} {
+		parsed, err := ParseAutocompleteRanking(string(ranking))
+		assert.NoError(t, err)
+		assert.Equal(t, ranking, parsed)
+	}
+
+	_, err = ParseAutocompleteRanking("random")
+	assert.Error(t, err)
+
+	// the weight formula makes the weight ranking the default one
+	assert.Equal(t, FirstFoundRanking, newQueryOptions(nil).autocompleteRanking())
+	assert.Equal(t, WeightRanking, newQueryOptions([]QueryOption{WithWeightFormula(LinearWeightFormula(0.5))}).autocompleteRanking())
+	assert.Equal(t, LengthRanking, newQueryOptions([]QueryOption{
+		WithWeightFormula(LinearWeightFormula(0.5)),
+		WithAutocompleteRanking(LengthRanking),
+	}).autocompleteRanking())
+}
+
+func TestRankedAutocomplete(t *testing.T) {
+	service, description := newTestService(t, "BMW X5 XDRIVE40I\t3\nBMW X5\t1\nBMW X5 M50D\t10\nAUDI Q5\t100\nBMW X5 M\n", func(description *IndexDescription) {
+		description.Format = WeightedPlainFormat
+	})
+
+	testCases := []struct {
+		name     string
+		opts     []QueryOption
+		expected []string
+	}{
+		{
+			name:     "first found",
+			opts:     nil,
+			expected: []string{"BMW X5 XDRIVE40I", "BMW X5"},
+		},
+		{
+			name:     "length",
+			opts:     []QueryOption{WithAutocompleteRanking(LengthRanking)},
+			expected: []string{"BMW X5", "BMW X5 M"},
+		},
+		{
+			name:     "weight",
+			opts:     []QueryOption{WithAutocompleteRanking(WeightRanking)},
+			expected: []string{"BMW X5 M50D", "BMW X5 XDRIVE40I"},
+		},
+		{
+			name:     "weight and length",
+			opts:     []QueryOption{WithWeightFormula(LinearWeightFormula(0.1))},
+			expected: []string{"BMW X5", "BMW X5 M"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			result, err := service.Autocomplete(context.Background(), description.Name, "BMW X", 2, testCase.opts...)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, resultValues(result))
+		})
+	}
+}
+
+func TestInfixAutocomplete(t *testing.T) {
+	service, description := newTestService(t, "Golf Club\nVolkswagen Golf\nMinigolf\nNissan\n", func(description *IndexDescription) {
+		description.Pad = "#"
+	})
+
+	testCases := []struct {
+		matching AutocompleteMatching
+		expected []string
+	}{
+		{PrefixMatching, []string{"Golf Club"}},
+		{WordBoundaryMatching, []string{"Golf Club", "Volkswagen Golf"}},
+		{InfixMatching, []string{"Golf Club", "Volkswagen Golf", "Minigolf"}},
+	}
+
+	for _, testCase := range testCases {
+		opts := []QueryOption{WithAutocompleteMatching(testCase.matching), WithAutocompleteRanking(LengthRanking)}
+		result, err := service.Autocomplete(context.Background(), description.Name, "golf", 5, opts...)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected[0], result[0].Value, testCase.matching)
+		assert.ElementsMatch(t, testCase.expected, resultValues(result), testCase.matching)
+	}
+
+	result, err := service.Autocomplete(context.Background(), description.Name, "golf", 2, WithAutocompleteMatching(InfixMatching))
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, "Golf Club", result[0].Value)
+
+	_, err = service.Autocomplete(context.Background(), description.Name, "golf", 5, WithAutocompleteMatching("suffix"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,118 @@
+package suggest
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// BatchResult is the result of a query of a batch
+type BatchResult struct {
+	// Items are the result items of the query
+	Items []ResultItem
+	// Err is the error of the query, if any. If the context is done before the query
+	// is finished, Items holds the candidates found so far and Err is the context error
+	Err error
+}
+
+// SuggestBatch performs Suggest for each of the given search configs in the dict and returns their
+// results in the order of the configs. The queries are run concurrently by at most workers goroutines,
+// GOMAXPROCS goroutines are used if workers is not positive. The index is acquired once for the whole batch,
+// so all queries are served by the same index even if it is replaced meanwhile.
+// An error of a query doesn't interrupt the others, it is reported by the result of the query
+func (s *Service) SuggestBatch(ctx context.Context, dictName string, configs []SearchConfig, workers int) ([]BatchResult, error) {
+	results := make([]BatchResult, 0, len(configs))
+	err := s.SuggestBatchFunc(ctx, dictName, configs, workers, func(i int, result BatchResult) error {
+		results = append(results, result)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// SuggestBatchFunc performs Suggest for each of the given search configs in the dict the same as SuggestBatch does,
+// but passes the result of each query to fn as soon as it and the results of all the previous queries are ready,
+// so fn is called in the order of the configs. The batch is stopped if fn returns an error, which is returned then
+func (s *Service) SuggestBatchFunc(
+	ctx context.Context,
+	dictName string,
+	configs []SearchConfig,
+	workers int,
+	fn func(i int, result BatchResult) error,
+) error {
+	current, release := s.acquire()
+	defer release()
+
+	entry, ok := current.entries[dictName]
+
+	if !ok {
+		return fmt.Errorf("given dictionary %s is not exists: %w", dictName, ErrDictionaryNotFound)
+	}
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+
+	if workers > len(configs) {
+		workers = len(configs)
+	}
+
+	results := make([]BatchResult, len(configs))
+	// done[i] is closed when the result of the i-th query is ready
+	done := make([]chan struct{}, len(configs))
+
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	queue := make(chan int)
+	stop := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for j := range queue {
+				items, err := entry.suggest(ctx, configs[j])
+				results[j] = BatchResult{
+					Items: items,
+					Err:   err,
+				}
+				close(done[j])
+			}
+		}()
+	}
+
+	go func() {
+		defer close(queue)
+
+		for i := range configs {
+			select {
+			case queue <- i:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	// the index is released only after all the workers are finished
+	defer wg.Wait()
+	defer close(stop)
+
+	for i := range configs {
+		<-done[i]
+
+		if err := fn(i, results[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestSuggestBatch(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	description := descriptions[0]
+	description.Driver = RAMDriver
+	service := NewService()
+	assert.NoError(t, service.AddRunTimeIndex(description))
+
+	queries := []string{"Nissan March", "Honda Fitt", "Wolfsvagen", "Tayota Corolla", "Micra Nissan", "Mersedes"}
+	configs := make([]SearchConfig, 0, len(queries))
+
+	for i, query := range queries {
+		m := metric.CosineMetric()
+
+		if i%2 == 1 {
+			m = metric.JaccardMetric()
+		}
+
+		searchConf, err := NewSearchConfig(query, 1+i%3, m, 0.5)
+		assert.NoError(t, err)
+
+		configs = append(configs, searchConf)
+	}
+
+	for _, workers := range []int{0, 1, 4, 100} {
+		results, err := service.SuggestBatch(context.Background(), description.Name, configs, workers)
+		assert.NoError(t, err)
+		assert.Len(t, results, len(configs))
+
+		for i, searchConf := range configs {
+			expected, err := service.Suggest(context.Background(), description.Name, searchConf)
+			assert.NoError(t, err)
+
+			assert.NoError(t, results[i].Err)
+			assert.Equal(t, resultValues(expected), resultValues(results[i].Items))
+		}
+	}
+
+	results, err := service.SuggestBatch(context.Background(), description.Name, nil, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, results)
+
+	_, err = service.SuggestBatch(context.Background(), "unknown", configs, 0)
+	assert.True(t, errors.Is(err, ErrDictionaryNotFound))
+
+	// the results are passed in the order of the configs, and the batch stops on the first error of fn
+	stopErr := errors.New("stop")
+	order := []int{}
+	err = service.SuggestBatchFunc(context.Background(), description.Name, configs, 4, func(i int, result BatchResult) error {
+		order = append(order, i)
+
+		if i == 2 {
+			return stopErr
+		}
+
+		return nil
+	})
+
+	assert.Equal(t, stopErr, err)
+	assert.Equal(t, []int{0, 1, 2}, order)
+}
@@ -0,0 +1,62 @@
+package suggest
+
+import (
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+)
+
+// forEachChange calls fn on each document changed by the given documents and deletes
+// with its value before the changes, looked up in prev, and its new value. The deleted
+// documents have the empty new value, the same as the added ones have the empty old value
+func forEachChange(
+	prev dictionary.Dictionary,
+	docs []Document,
+	deletes []dictionary.Key,
+	fn func(key dictionary.Key, old, value dictionary.Value),
+) error {
+	values := make(map[dictionary.Key]dictionary.Value, len(docs)+len(deletes))
+
+	for _, key := range deletes {
+		values[key] = ""
+	}
+
+	for _, doc := range docs {
+		values[doc.Key] = doc.Value
+	}
+
+	for key, value := range values {
+		old, err := prev.Get(key)
+
+		if err != nil {
+			return err
+		}
+
+		if old == dictionary.NilValue {
+			old = ""
+		}
+
+		fn(key, old, value)
+	}
+
+	return nil
+}
+
+// copyOnWriteLists copies the posting lists, that are shared with another index, before they are changed
+type copyOnWriteLists map[*roaring.Bitmap]struct{}
+
+// newCopyOnWriteLists creates a new instance of copyOnWriteLists
+func newCopyOnWriteLists() copyOnWriteLists {
+	return copyOnWriteLists{}
+}
+
+// get returns the list, that can be changed, the given list is copied if it is not one of the copies
+func (c copyOnWriteLists) get(list *roaring.Bitmap) *roaring.Bitmap {
+	if _, ok := c[list]; ok {
+		return list
+	}
+
+	list = list.Clone()
+	c[list] = struct{}{}
+
+	return list
+}
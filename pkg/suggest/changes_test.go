@@ -0,0 +1,109 @@
+package suggest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+)
+
+func TestForEachChange(t *testing.T) {
+	prev := dictionary.NewInMemoryDictionary([]string{"BMW X5", "BMW X6", "AUDI Q5"})
+	changes := map[dictionary.Key][2]dictionary.Value{}
+
+	err := forEachChange(prev, []Document{
+		{Key: 0, Value: "BMW X5 M"},
+		{Key: 3, Value: "AUDI Q7"},
+		{Key: 2, Value: "AUDI A4"},
+	}, []dictionary.Key{1, 2}, func(key dictionary.Key, old, value dictionary.Value) {
+		changes[key] = [2]dictionary.Value{old, value}
+	})
+	assert.NoError(t, err)
+
+	// the updated document of the deleted key is kept
+	assert.Equal(t, map[dictionary.Key][2]dictionary.Value{
+		0: {"BMW X5", "BMW X5 M"},
+		1: {"BMW X6", ""},
+		2: {"AUDI Q5", "AUDI A4"},
+		3: {"", "AUDI Q7"},
+	}, changes)
+}
+
+func TestCopyOnWriteLists(t *testing.T) {
+	shared := roaring.BitmapOf(1, 2)
+	lists := newCopyOnWriteLists()
+
+	list := lists.get(shared)
+	list.Add(3)
+
+	assert.NotSame(t, shared, list)
+	assert.Equal(t, []uint32{1, 2}, shared.ToArray())
+
+	// the copy is changed in place from then on
+	assert.Same(t, list, lists.get(list))
+	assert.Equal(t, []uint32{1, 2, 3}, list.ToArray())
+}
+
+func TestUpdateTextIndexes(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	description := copyOnDiscIndex(t, descriptions[0])
+	defer os.RemoveAll(description.OutputPath)
+
+	description.WordSearch = true
+	description.Phonetic = []string{"doubleMetaphone"}
+
+	service := NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+
+	prev := service.current.entries[description.Name]
+	prevNissan := prev.words.documents[prev.words.positions["nissan"]].Clone()
+
+	assert.NoError(t, service.UpdateDocuments(description.Name, []Document{
+		{Key: 0, Value: "NISSAN VESTA"},
+		{Key: 100000, Value: "LADA VESTA"},
+	}))
+	assert.NoError(t, service.DeleteDocuments(description.Name, []dictionary.Key{1, 100000}))
+
+	entry := service.current.entries[description.Name]
+	assert.NotSame(t, prev.words, entry.words)
+	assert.True(t, prevNissan.Equals(prev.words.documents[prev.words.positions["nissan"]]))
+
+	// the derived indexes should be the same as the ones built from scratch
+	words, err := newWordIndex(entry.dictionary, description)
+	assert.NoError(t, err)
+
+	for position, word := range entry.words.vocabulary {
+		expected := roaring.New()
+
+		if i, ok := words.positions[word]; ok {
+			expected = words.documents[i]
+		}
+
+		assert.True(t, expected.Equals(entry.words.documents[position]), word)
+	}
+
+	phonetic, err := newPhoneticIndex(entry.dictionary, description)
+	assert.NoError(t, err)
+
+	for code, list := range entry.phonetic.documents[0] {
+		expected := roaring.New()
+
+		if documents, ok := phonetic.documents[0][code]; ok {
+			expected = documents
+		}
+
+		assert.True(t, expected.Equals(list), code)
+	}
+
+	for _, word := range words.vocabulary {
+		assert.Contains(t, entry.words.positions, word)
+	}
+
+	for code := range phonetic.documents[0] {
+		assert.Contains(t, entry.phonetic.documents[0], code)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"math"
 
+	"github.com/RoaringBitmap/roaring"
 	"github.com/suggest-go/suggest/pkg/index"
 	"github.com/suggest-go/suggest/pkg/merger"
 )
@@ -42,6 +43,22 @@ type CollectorManager interface {
 	GetCandidates() []Candidate
 }
 
+// boundedCollectorManager is a CollectorManager, that can tell in advance whether
+// it is worth to collect candidates with the given upper bound of their scores
+type boundedCollectorManager interface {
+	CollectorManager
+	// CanTakeWithScore returns true if a candidate with the given score can be accepted
+	CanTakeWithScore(score float64) bool
+}
+
+// lowestScoreCollectorManager is a CollectorManager, that exposes the lowest score of the collected
+// candidates, so a candidate with a lower score is not going to be accepted
+type lowestScoreCollectorManager interface {
+	CollectorManager
+	// GetLowestScore returns the lowest collected score
+	GetLowestScore() float64
+}
+
 // CollectorManagerFactory is a factory method for creating a new instance of CollectorManager.
 type CollectorManagerFactory func() CollectorManager
 
@@ -181,6 +198,11 @@ func (m *FuzzyCollectorManager) GetCandidates() []Candidate {
 	return m.globalQueue.GetCandidates()
 }
 
+// CanTakeWithScore returns true if a candidate with the given score can be accepted
+func (m *FuzzyCollectorManager) CanTakeWithScore(score float64) bool {
+	return m.globalQueue.CanTakeWithScore(score)
+}
+
 // GetLowestScore returns the lowest collected score.
 func (m *FuzzyCollectorManager) GetLowestScore() float64 {
 	if !m.globalQueue.IsFull() {
@@ -189,3 +211,245 @@ func (m *FuzzyCollectorManager) GetLowestScore() float64 {
 
 	return m.globalQueue.GetLowestScore()
 }
+
+type weightedCollector struct {
+	topKQueue TopKQueue
+	scorer    Scorer
+	weights   Weights
+	formula   WeightFormula
+}
+
+// Collect collects the given merge candidate with its blended score
+func (c *weightedCollector) Collect(item merger.MergeCandidate) error {
+	c.topKQueue.Add(item.Position(), c.scorer.Score(item))
+
+	return nil
+}
+
+// SetScorer sets a scorer, which score is blended with the document weight
+func (c *weightedCollector) SetScorer(scorer Scorer) {
+	c.scorer = NewWeightScorer(scorer, c.weights, c.formula)
+}
+
+// NewWeightedCollectorManager creates a new instance of WeightedCollectorManager.
+func NewWeightedCollectorManager(weights Weights, formula WeightFormula, queueFactory func() TopKQueue) *WeightedCollectorManager {
+	return &WeightedCollectorManager{
+		weights:      weights,
+		formula:      formula,
+		queueFactory: queueFactory,
+		globalQueue:  queueFactory(),
+	}
+}
+
+func newWeightedCollectorManager(topK int, weights Weights, formula WeightFormula) CollectorManagerFactory {
+	return func() CollectorManager {
+		return NewWeightedCollectorManager(weights, formula, func() TopKQueue {
+			return NewTopKQueue(topK)
+		})
+	}
+}
+
+// WeightedCollectorManager represents a collector manager, that ranks candidates by blending
+// their scores with the document weights. Unlike FuzzyCollectorManager it doesn't expose
+// the lowest collected score, because a heavy document with a lower similarity can
+// still outrank the collected candidates
+type WeightedCollectorManager struct {
+	weights      Weights
+	formula      WeightFormula
+	queueFactory func() TopKQueue
+	globalQueue  TopKQueue
+}
+
+// Create creates a new collector that will be used for a search segment.
+// Until a scorer is set, candidates are ranked only by their weights
+func (m *WeightedCollectorManager) Create() Collector {
+	collector := &weightedCollector{
+		topKQueue: m.queueFactory(),
+		weights:   m.weights,
+		formula:   m.formula,
+	}
+
+	collector.SetScorer(constScorer(1))
+
+	return collector
+}
+
+// Collect returns back the given collectors.
+func (m *WeightedCollectorManager) Collect(collectors ...Collector) error {
+	for _, item := range collectors {
+		collector, ok := item.(*weightedCollector)
+
+		if !ok {
+			return errors.New("expected Collector created by WeightedCollectorManager")
+		}
+
+		m.globalQueue.Merge(collector.topKQueue)
+	}
+
+	return nil
+}
+
+// GetCandidates returns currently collected candidates.
+func (m *WeightedCollectorManager) GetCandidates() []Candidate {
+	return m.globalQueue.GetCandidates()
+}
+
+// CanTakeWithScore returns true if a candidate with the given score of the underlying scorer
+// can be accepted, assuming that the candidate has the biggest weight
+func (m *WeightedCollectorManager) CanTakeWithScore(score float64) bool {
+	return m.globalQueue.CanTakeWithScore(m.formula(score, m.weights.Max(), m.weights.Max()))
+}
+
+// filteredCollector is a Collector, that tells Searcher to find only the documents of the filter
+type filteredCollector struct {
+	Collector
+	filter *roaring.Bitmap
+}
+
+// Filter returns the set of documents allowed to be collected
+func (c *filteredCollector) Filter() *roaring.Bitmap {
+	return c.filter
+}
+
+// newFilteredCollectorManager wraps the collector managers of the given factory,
+// so their collectors receive only the documents of the filter
+func newFilteredCollectorManager(factory CollectorManagerFactory, filter *roaring.Bitmap) CollectorManagerFactory {
+	return func() CollectorManager {
+		return &filteredCollectorManager{
+			CollectorManager: factory(),
+			filter:           filter,
+		}
+	}
+}
+
+// filteredCollectorManager is a CollectorManager, which collectors receive only the documents of the filter
+type filteredCollectorManager struct {
+	CollectorManager
+	filter *roaring.Bitmap
+}
+
+// Create creates a new collector that will be used for a search segment
+func (m *filteredCollectorManager) Create() Collector {
+	return &filteredCollector{
+		Collector: m.CollectorManager.Create(),
+		filter:    m.filter,
+	}
+}
+
+// Collect returns back the given collectors.
+func (m *filteredCollectorManager) Collect(collectors ...Collector) error {
+	unwrapped := make([]Collector, 0, len(collectors))
+
+	for _, item := range collectors {
+		collector, ok := item.(*filteredCollector)
+
+		if !ok {
+			return errors.New("expected Collector created by filteredCollectorManager")
+		}
+
+		unwrapped = append(unwrapped, collector.Collector)
+	}
+
+	return m.CollectorManager.Collect(unwrapped...)
+}
+
+// CanTakeWithScore returns true if a candidate with the given score can be accepted
+// by the wrapped collector manager
+func (m *filteredCollectorManager) CanTakeWithScore(score float64) bool {
+	if bounded, ok := m.CollectorManager.(boundedCollectorManager); ok {
+		return bounded.CanTakeWithScore(score)
+	}
+
+	return true
+}
+
+// GetLowestScore returns the lowest collected score of the wrapped collector manager
+func (m *filteredCollectorManager) GetLowestScore() float64 {
+	if lowest, ok := m.CollectorManager.(lowestScoreCollectorManager); ok {
+		return lowest.GetLowestScore()
+	}
+
+	return math.Inf(-1)
+}
+
+// newVerifiedCollectorManager wraps the collector managers of the given factory,
+// so their collectors receive only the documents, that pass the verification
+func newVerifiedCollectorManager(factory CollectorManagerFactory, verify func(key index.Position) (bool, error)) CollectorManagerFactory {
+	return func() CollectorManager {
+		return &verifiedCollectorManager{
+			CollectorManager: factory(),
+			verify:           verify,
+		}
+	}
+}
+
+// verifiedCollectorManager is a CollectorManager, which collectors receive only the verified documents
+type verifiedCollectorManager struct {
+	CollectorManager
+	verify func(key index.Position) (bool, error)
+}
+
+// Create creates a new collector that will be used for a search segment
+func (m *verifiedCollectorManager) Create() Collector {
+	return &verifiedCollector{
+		Collector: m.CollectorManager.Create(),
+		verify:    m.verify,
+	}
+}
+
+// Collect returns back the given collectors.
+func (m *verifiedCollectorManager) Collect(collectors ...Collector) error {
+	unwrapped := make([]Collector, 0, len(collectors))
+
+	for _, item := range collectors {
+		collector, ok := item.(*verifiedCollector)
+
+		if !ok {
+			return errors.New("expected Collector created by verifiedCollectorManager")
+		}
+
+		unwrapped = append(unwrapped, collector.Collector)
+	}
+
+	return m.CollectorManager.Collect(unwrapped...)
+}
+
+// CanTakeWithScore returns true if a candidate with the given score can be accepted
+// by the wrapped collector manager
+func (m *verifiedCollectorManager) CanTakeWithScore(score float64) bool {
+	if bounded, ok := m.CollectorManager.(boundedCollectorManager); ok {
+		return bounded.CanTakeWithScore(score)
+	}
+
+	return true
+}
+
+// GetLowestScore returns the lowest collected score of the wrapped collector manager
+func (m *verifiedCollectorManager) GetLowestScore() float64 {
+	if lowest, ok := m.CollectorManager.(lowestScoreCollectorManager); ok {
+		return lowest.GetLowestScore()
+	}
+
+	return math.Inf(-1)
+}
+
+// verifiedCollector is a Collector, that skips the documents failed the verification
+type verifiedCollector struct {
+	Collector
+	verify func(key index.Position) (bool, error)
+}
+
+// Collect collects the given candidate, if it passes the verification
+func (c *verifiedCollector) Collect(item merger.MergeCandidate) error {
+	ok, err := c.verify(item.Position())
+
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return nil
+	}
+
+	return c.Collector.Collect(item)
+}
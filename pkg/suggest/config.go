@@ -21,6 +21,49 @@ const (
 	DiscDriver Driver = "DISC"
 )
 
+// SourceFormat represents a format of a dictionary source
+type SourceFormat string
+
+const (
+	// PlainFormat means that each line of a source is a document value as is
+	PlainFormat SourceFormat = "plain"
+	// WeightedPlainFormat means that each line of a source is a document value
+	// optionally followed by a tab and a non-negative document weight
+	WeightedPlainFormat SourceFormat = "weighted"
+	// JSONLinesFormat means that each line of a source is a JSON object, which configured
+	// field is indexed and the rest fields are stored as the document payload
+	JSONLinesFormat SourceFormat = "jsonl"
+)
+
+// TokenizerType is a way to split documents and queries into the indexed terms
+type TokenizerType string
+
+const (
+	// NGramTokenizer splits a text into the n-grams of NGramSize
+	NGramTokenizer TokenizerType = "ngram"
+	// VGramTokenizer splits a text into the variable length grams, which are chosen by their frequency
+	// in the dictionary, so the frequent n-grams are replaced with the longer and more selective grams.
+	// The grams are tuned by VGramDescription. Autocomplete is not supported by such index
+	VGramTokenizer TokenizerType = "vgram"
+)
+
+const (
+	// defaultVGramExtension is the difference between the maximal and the minimal gram lengths by default
+	defaultVGramExtension = 2
+	// defaultVGramThreshold is the number of occurrences, after which a gram is extended by default
+	defaultVGramThreshold = 100
+)
+
+// VGramDescription tunes the variable length grams of VGramTokenizer
+type VGramDescription struct {
+	// QMin is the minimal gram length, NGramSize by default
+	QMin int `json:"qMin"`
+	// QMax is the maximal gram length, QMin + 2 by default
+	QMax int `json:"qMax"`
+	// Threshold is the number of occurrences, after which a gram is extended to the longer ones, 100 by default
+	Threshold int `json:"threshold"`
+}
+
 // IndexDescription is config for NgramIndex structure
 type IndexDescription struct {
 	Driver     Driver    `json:"driver"`
@@ -31,7 +74,40 @@ type IndexDescription struct {
 	Alphabet   []string  `json:"alphabet"`
 	Pad        string    `json:"pad"`
 	Wrap       [2]string `json:"wrap"`
-	basePath   string
+	// Format is a format of the source, PlainFormat is used by default
+	Format SourceFormat `json:"format"`
+	// Field is a field of a JSONLinesFormat source document to index
+	Field string `json:"field"`
+	// WeightField is an optional numeric field of a JSONLinesFormat source document with the document weight
+	WeightField string `json:"weightField"`
+	// Attributes are the fields of a JSONLinesFormat source document, that can be used to filter queries.
+	// A field value should be a string, a number or a list of them
+	Attributes []string `json:"attributes"`
+	// Transliteration is a name of the scheme, that replaces Cyrillic letters of documents and queries with Latin ones,
+	// so a word matches its Latin spelling, i.e. "gost", "iso9" or "informal". The alphabet should contain Latin letters
+	Transliteration string `json:"transliteration"`
+	// Normalization brings documents and queries to the canonical Unicode form before the transliteration,
+	// i.e. folds their case and strips diacritics, so "Škoda" matches "skoda"
+	Normalization *analysis.UnicodeNormalization `json:"normalization"`
+	// Analysis is an ordered list of the tokenizers and filters, that are applied to documents and queries
+	// after the normalization and the transliteration and before the n-gram split,
+	// i.e. [{"type": "word"}, {"type": "stemmer", "lang": "en"}]. See analysis.RegisterFilter.
+	// If any of Normalization, Transliteration and Analysis is set, the text is lowercased
+	// before all of them, so the declared stages always receive lowercase tokens
+	Analysis []analysis.Definition `json:"analysis"`
+	// WordSearch enables the queries, that match each query word separately, see WithWordMatching.
+	// The words are split after the normalization, transliteration and analysis stages, so they are compared
+	// in the same form as the n-grams are. The index of the document words is built in RAM when the index is opened
+	WordSearch bool `json:"wordSearch"`
+	// Phonetic is a list of the phonetic encoders, i.e. "doubleMetaphone", "soundex" or "russianMetaphone",
+	// which keys of the document words are indexed, see WithPhoneticBoost. The keys are persisted along with
+	// the segments of an on-disc index, the segments written without them are encoded when the index is opened
+	Phonetic []string `json:"phonetic"`
+	// Tokenizer is a way to split documents and queries into the indexed terms, NGramTokenizer by default
+	Tokenizer TokenizerType `json:"tokenizer"`
+	// VGram tunes the grams of VGramTokenizer
+	VGram    VGramDescription `json:"vgram"`
+	basePath string
 }
 
 // GetDictionaryFile returns a path to a dictionary file from the configuration
@@ -39,6 +115,21 @@ func (d *IndexDescription) GetDictionaryFile() string {
 	return fmt.Sprintf("%s/%s.cdb", d.GetIndexPath(), d.Name)
 }
 
+// GetSegmentDictionaryFile returns a path to a dictionary file of the given index segment
+func (d *IndexDescription) GetSegmentDictionaryFile(segment string) string {
+	return fmt.Sprintf("%s/%s.cdb", d.GetIndexPath(), segment)
+}
+
+// GetPayloadFile returns a path to a document payloads file from the configuration
+func (d *IndexDescription) GetPayloadFile() string {
+	return d.GetSegmentPayloadFile(d.Name)
+}
+
+// GetSegmentPayloadFile returns a path to a document payloads file of the given index segment
+func (d *IndexDescription) GetSegmentPayloadFile(segment string) string {
+	return fmt.Sprintf("%s/%s.payload.cdb", d.GetIndexPath(), segment)
+}
+
 // GetIndexPath returns a output path of the built index
 func (d *IndexDescription) GetIndexPath() string {
 	if !path.IsAbs(d.OutputPath) {
@@ -62,14 +153,112 @@ func (d *IndexDescription) GetWriterConfig() index.WriterConfig {
 	return index.WriterConfig{
 		HeaderFileName:       d.getHeaderFile(),
 		DocumentListFileName: d.getDocumentListFile(),
+		DocumentSetFileName:  d.getDocumentSetFile(),
 	}
 }
 
-// GetIndexTokenizer returns a tokenizer for indexing
-func (d *IndexDescription) GetIndexTokenizer() analysis.Tokenizer {
+// GetIndexTokenizer returns a tokenizer for indexing with NGramTokenizer,
+// see BuildIndexTokenizer and OpenIndexTokenizer for the rest tokenizers
+func (d *IndexDescription) GetIndexTokenizer() (analysis.Tokenizer, error) {
 	return NewSuggestTokenizer(*d)
 }
 
+// validate checks the analysis settings of the index description
+func (d *IndexDescription) validate() error {
+	if _, err := d.analysisStages(); err != nil {
+		return err
+	}
+
+	switch d.Tokenizer {
+	case "", NGramTokenizer:
+	case VGramTokenizer:
+		if qMin, qMax, _ := d.vgramBounds(); qMin < 1 || qMin > qMax {
+			return fmt.Errorf("vgram lengths should satisfy 1 <= qMin <= qMax, got [%d, %d]", qMin, qMax)
+		}
+	default:
+		return fmt.Errorf("tokenizer %s is not supported", d.Tokenizer)
+	}
+
+	_, err := d.phoneticEncoders()
+
+	return err
+}
+
+// vgramBounds returns the minimal and the maximal gram lengths and the frequency threshold
+// of VGramTokenizer, the missing settings are replaced with the default ones
+func (d *IndexDescription) vgramBounds() (qMin, qMax, threshold int) {
+	qMin, qMax, threshold = d.VGram.QMin, d.VGram.QMax, d.VGram.Threshold
+
+	if qMin == 0 {
+		qMin = d.NGramSize
+	}
+
+	if qMax == 0 {
+		qMax = qMin + defaultVGramExtension
+	}
+
+	if threshold == 0 {
+		threshold = defaultVGramThreshold
+	}
+
+	return qMin, qMax, threshold
+}
+
+// phoneticEncoders returns the phonetic encoders of the index description
+func (d *IndexDescription) phoneticEncoders() ([]analysis.PhoneticEncoder, error) {
+	encoders := make([]analysis.PhoneticEncoder, 0, len(d.Phonetic))
+
+	for _, name := range d.Phonetic {
+		encoder, err := analysis.GetPhoneticEncoder(name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		encoders = append(encoders, encoder)
+	}
+
+	return encoders, nil
+}
+
+// analysisStages returns the stages of the analysis pipeline of the index description.
+// The text is lowercased first, then it is normalized and transliterated, if it is configured
+func (d *IndexDescription) analysisStages() ([]analysis.Stage, error) {
+	if d.Normalization == nil && d.Transliteration == "" && len(d.Analysis) == 0 {
+		return nil, nil
+	}
+
+	stages := []analysis.Stage{analysis.FilterStage(analysis.NewLowercaseFilter())}
+
+	if d.Normalization != nil {
+		normalizer, err := analysis.NewUnicodeNormalizerFilter(*d.Normalization)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stages = append(stages, analysis.FilterStage(normalizer))
+	}
+
+	if d.Transliteration != "" {
+		transliteration, err := analysis.GetTransliteration(d.Transliteration)
+
+		if err != nil {
+			return nil, err
+		}
+
+		stages = append(stages, analysis.FilterStage(analysis.NewTransliterationFilter(transliteration)))
+	}
+
+	pipeline, err := analysis.NewStages(d.Analysis)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(stages, pipeline...), nil
+}
+
 // getHeaderFile returns a path to a header file from the configuration
 func (d *IndexDescription) getHeaderFile() string {
 	return fmt.Sprintf("%s.hd", d.Name)
@@ -80,6 +269,11 @@ func (d *IndexDescription) getDocumentListFile() string {
 	return fmt.Sprintf("%s.dl", d.Name)
 }
 
+// getDocumentSetFile returns a path to a document set file from the configuration
+func (d *IndexDescription) getDocumentSetFile() string {
+	return fmt.Sprintf("%s.ids", d.Name)
+}
+
 // ReadConfigs reads and returns a list of IndexDescription from the given reader
 func ReadConfigs(configPath string) ([]IndexDescription, error) {
 	configFile, err := os.Open(configPath)
@@ -104,6 +298,10 @@ func ReadConfigs(configPath string) ([]IndexDescription, error) {
 	basePath := path.Dir(configPath)
 
 	for i, c := range configs {
+		if err := c.validate(); err != nil {
+			return nil, fmt.Errorf("invalid description of %s: %w", c.Name, err)
+		}
+
 		c.basePath = basePath
 		configs[i] = c
 	}
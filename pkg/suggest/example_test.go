@@ -2,6 +2,7 @@
 package suggest_test
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -55,7 +56,7 @@ func Example() {
 		log.Fatalf("Unexpected error: %v", err)
 	}
 
-	result, err := service.Suggest("cars", searchConf)
+	result, err := service.Suggest(context.Background(), "cars", searchConf)
 
 	if err != nil {
 		log.Fatalf("Unexpected error: %v", err)
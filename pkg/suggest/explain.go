@@ -0,0 +1,103 @@
+package suggest
+
+import (
+	"fmt"
+
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+// Explanation describes how the score of a Suggest candidate has been computed
+type Explanation struct {
+	// Metric is a name of the metric, that compares the n-gram sets of the query and the candidate
+	Metric string
+	// Similarity is the minimal similarity requested by the query
+	Similarity float64
+	// SizeA is the number of the query n-grams
+	SizeA int
+	// SizeB is the number of the candidate n-grams, i.e. the length bucket of the index, that has been searched
+	SizeB int
+	// Threshold is the minimal number of the shared n-grams, that a candidate of the bucket should have
+	Threshold int
+	// Overlap is the number of the shared n-grams
+	Overlap int
+	// SharedNGrams are the n-grams of the query, that the candidate has
+	SharedNGrams []string
+	// PostingLists holds the sizes of the posting lists of the query n-grams in the searched bucket
+	PostingLists map[string]int
+	// Distance is the metric distance between the n-gram sets, or the re-scored distance
+	// if the metric is a metric.WeightedMetric or a metric.StringMetric
+	Distance float64
+	// MetricScore is the score given by the metric, which is 1 - Distance
+	MetricScore float64
+	// Weight is the document weight, that has been blended with MetricScore by the weight formula of the query
+	Weight *float64 `json:",omitempty"`
+	// Reranked tells that the final score has been given by the edit distance reranking
+	Reranked bool `json:",omitempty"`
+}
+
+// WithExplain makes Suggest attach the explanation of its score to each result item.
+// It can't be combined with WithWordMatching, WithPhoneticBoost and WithKeyboardLayouts
+func WithExplain() QueryOption {
+	return func(options *queryOptions) {
+		options.explain = true
+	}
+}
+
+// explainer is a Suggester, that can explain the score of a candidate
+type explainer interface {
+	// Explain returns the explanation of the score of the candidate with the given value
+	Explain(query, value string, similarity float64, metric metric.Metric) (Explanation, error)
+}
+
+// Explain returns the explanation of the score of the candidate with the given value.
+// The value is tokenized again to find its length bucket and the shared n-grams
+func (n *nGramSuggester) Explain(query, value string, similarity float64, metric metric.Metric) (Explanation, error) {
+	tokens := n.tokenizer.Tokenize(query)
+	candidate := map[index.Term]struct{}{}
+
+	for _, token := range n.tokenizer.Tokenize(value) {
+		candidate[token] = struct{}{}
+	}
+
+	explanation := Explanation{
+		Metric:       fmt.Sprint(metric),
+		Similarity:   similarity,
+		SizeA:        len(tokens),
+		SizeB:        len(candidate),
+		SharedNGrams: []string{},
+		PostingLists: make(map[string]int, len(tokens)),
+	}
+
+	if len(tokens) == 0 || len(candidate) == 0 {
+		return explanation, nil
+	}
+
+	invertedIndex := n.indices.Get(explanation.SizeB)
+
+	for _, token := range tokens {
+		if _, ok := candidate[token]; ok {
+			explanation.SharedNGrams = append(explanation.SharedNGrams, token)
+		}
+
+		if invertedIndex == nil || !invertedIndex.Has(token) {
+			explanation.PostingLists[token] = 0
+			continue
+		}
+
+		list, err := invertedIndex.Get(token)
+
+		if err != nil {
+			return Explanation{}, fmt.Errorf("failed to retrieve a posting list context: %w", err)
+		}
+
+		explanation.PostingLists[token] = list.ListSize
+	}
+
+	explanation.Overlap = len(explanation.SharedNGrams)
+	explanation.Threshold = metric.Threshold(similarity, explanation.SizeA, explanation.SizeB)
+	explanation.Distance = metric.Distance(explanation.Overlap, explanation.SizeA, explanation.SizeB)
+	explanation.MetricScore = 1 - explanation.Distance
+
+	return explanation, nil
+}
@@ -0,0 +1,50 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestExplain(t *testing.T) {
+	service, description := newTestService(t, "Mercedes\nMercury\n", nil)
+
+	searchConf, err := NewSearchConfig("mersedes", 1, metric.JaccardMetric(), 0.3, WithExplain())
+	assert.NoError(t, err)
+
+	result, err := service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, "Mercedes", result[0].Value)
+
+	// $me mer ers rse sed ede des es$ against $me mer erc rce ced ede des es$
+	explanation := result[0].Explanation
+	assert.Equal(t, "Jaccard", explanation.Metric)
+	assert.Equal(t, 8, explanation.SizeA)
+	assert.Equal(t, 8, explanation.SizeB)
+	assert.Equal(t, 4, explanation.Threshold)
+	assert.Equal(t, []string{"$me", "mer", "ede", "des", "es$"}, explanation.SharedNGrams)
+	assert.Equal(t, 5, explanation.Overlap)
+	assert.Equal(t, 1, explanation.PostingLists["mer"])
+	assert.Equal(t, 0, explanation.PostingLists["rse"])
+	assert.Equal(t, result[0].Score, explanation.MetricScore)
+	assert.Nil(t, explanation.Weight)
+
+	searchConf, err = NewSearchConfig("mersedes", 1, metric.JaccardMetric(), 0.3)
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Nil(t, result[0].Explanation)
+
+	_, err = NewSearchConfig("mersedes", 1, metric.JaccardMetric(), 0.3, WithExplain(), WithWordMatching(AnyWordMatching))
+	assert.Error(t, err)
+
+	_, err = NewSearchConfig("mersedes", 1, metric.JaccardMetric(), 0.3, WithExplain(), WithPhoneticBoost(0.5))
+	assert.Error(t, err)
+
+	_, err = NewSearchConfig("mersedes", 1, metric.JaccardMetric(), 0.3, WithExplain(), WithKeyboardLayouts(0.9, analysis.QwertyToJcukenLayout))
+	assert.Error(t, err)
+}
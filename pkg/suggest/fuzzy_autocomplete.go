@@ -0,0 +1,81 @@
+package suggest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+// typoCandidatesFactor tells how many times more candidates than requested are looked up by
+// a typo-tolerant autocomplete, because some of them are dropped by the verification
+const typoCandidatesFactor = 4
+
+// prefixDistance is the distance between a query and the closest prefix of a candidate,
+// that verifies the candidates of a typo-tolerant autocomplete
+var prefixDistance = metric.PrefixDistance(metric.DamerauLevenshteinDistance(metric.DefaultEditCosts))
+
+// WithAutocompleteTypos makes Autocomplete tolerate at most maxErrors typos in the query, i.e. "mercde"
+// completes to "Mercedes". A typo is an insertion, a deletion, a substitution or a transposition of
+// adjacent characters. The candidates, that have the query as an exact prefix, come first, the rest
+// ones follow them in the ascending order of the typos
+func WithAutocompleteTypos(maxErrors int) QueryOption {
+	return func(options *queryOptions) {
+		options.typos = maxErrors
+	}
+}
+
+// fuzzyAutocomplete is an Autocomplete, that tolerates typos in the query
+type fuzzyAutocomplete interface {
+	Autocomplete
+	// FuzzyAutocomplete returns candidates, which prefix differs from the query string by at most maxErrors edits
+	FuzzyAutocomplete(ctx context.Context, query string, maxErrors int, factory CollectorManagerFactory) ([]Candidate, error)
+}
+
+// autocompleteWithTypos returns candidates, which prefix differs from the query by at most maxErrors edits.
+// The found candidates are verified against their values from the dictionary, both the query and the values
+// are brought to the analyzed form with analyze, the same as the n-grams are made of. The candidates are stably
+// sorted by the number of typos, so the candidates of the same number keep the order of the collector
+func autocompleteWithTypos(
+	ctx context.Context,
+	autocomplete Autocomplete,
+	dict dictionary.Dictionary,
+	analyze func(text string) string,
+	query string,
+	maxErrors int,
+	factory CollectorManagerFactory,
+) ([]Candidate, error) {
+	fuzzy, ok := autocomplete.(fuzzyAutocomplete)
+
+	if !ok {
+		return nil, fmt.Errorf("typo-tolerant autocomplete is not supported by the index")
+	}
+
+	candidates, searchErr := fuzzy.FuzzyAutocomplete(ctx, query, maxErrors, factory)
+	query = analyze(query)
+	verified := make([]Candidate, 0, len(candidates))
+	typos := make(map[dictionary.Key]float64, len(candidates))
+
+	for _, candidate := range candidates {
+		value, err := dict.Get(candidate.Key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		distance := prefixDistance.Distance(query, analyze(value), float64(maxErrors))
+
+		if distance <= float64(maxErrors) {
+			typos[candidate.Key] = distance
+			verified = append(verified, candidate)
+		}
+	}
+
+	sort.SliceStable(verified, func(i, j int) bool {
+		return typos[verified[i].Key] < typos[verified[j].Key]
+	})
+
+	return verified, searchErr
+}
@@ -0,0 +1,47 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutocompleteTypos(t *testing.T) {
+	service, description := newTestService(t, "Mercedes Benz\nMercury Cougar\nMercde\nMazda 6\n", nil)
+
+	result, err := service.Autocomplete(context.Background(), description.Name, "mercde", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercde"}, resultValues(result))
+
+	result, err = service.Autocomplete(context.Background(), description.Name, "mercde", 5, WithAutocompleteTypos(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercde", "Mercedes Benz"}, resultValues(result))
+
+	// "mecred" is a single transposition away from "merced" and two ones away from "mercde"
+	result, err = service.Autocomplete(context.Background(), description.Name, "mecred", 5, WithAutocompleteTypos(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercedes Benz", "Mercde"}, resultValues(result))
+
+	_, err = service.Autocomplete(context.Background(), description.Name, "mercde", 5, WithAutocompleteTypos(-1))
+	assert.Error(t, err)
+
+	_, err = service.Autocomplete(context.Background(), description.Name, "mercde", 5, WithAutocompleteTypos(1), WithAutocompleteMatching(InfixMatching))
+	assert.Error(t, err)
+}
+
+func TestAutocompleteTyposAnalyzed(t *testing.T) {
+	service, description := newTestService(t, "Жигули\nMercedes-Benz\nMazda 6\n", func(description *IndexDescription) {
+		description.Transliteration = "informal"
+	})
+
+	// the typos are counted on the transliterated values
+	result, err := service.Autocomplete(context.Background(), description.Name, "zhgul", 5, WithAutocompleteTypos(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Жигули"}, resultValues(result))
+
+	// the word separators are normalized the same way for the query and the values
+	result, err = service.Autocomplete(context.Background(), description.Name, "mercedes bnz", 5, WithAutocompleteTypos(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercedes-Benz"}, resultValues(result))
+}
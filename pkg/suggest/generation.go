@@ -0,0 +1,432 @@
+package suggest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+// indexEntry is a search index managed by Service along with its stored data
+type indexEntry struct {
+	index      NGramIndex
+	dictionary dictionary.Dictionary
+	weights    Weights
+	// payloads holds the document payloads, the documents without payloads are absent
+	payloads   dictionary.Dictionary
+	attributes Attributes
+	// words is an index of the document words, it is nil if the word search is disabled
+	words *wordIndex
+	// phonetic is an index of the phonetic keys of the document words, it is nil if no encoder is described
+	phonetic *phoneticIndex
+	// highlighter highlights the query in the results, the lowercased query and results are compared if it is nil
+	highlighter *highlighter
+	// verifier verifies the infix and the typo-tolerant autocomplete candidates, the infix candidates
+	// aren't verified and the typos are counted on the lowercased text if it is nil
+	verifier *candidateVerifier
+	// segments is the generation of the segments of the on-disc index, the entry has been opened with
+	segments uint32
+	// refs is the number of generations that hold the entry, the entry is closed
+	// when the last of them is retired
+	refs int32
+}
+
+// newIndexEntry creates a new instance of indexEntry, the missing weights, payloads
+// and attributes are treated as empty ones. The entry is created with a single reference,
+// that is owned by the generation the entry is added to
+func newIndexEntry(
+	nGramIndex NGramIndex,
+	dict dictionary.Dictionary,
+	weights Weights,
+	payloads dictionary.Dictionary,
+	attributes Attributes,
+) *indexEntry {
+	if weights == nil {
+		weights = NewWeights(nil)
+	}
+
+	if attributes == nil {
+		attributes = Attributes{}
+	}
+
+	if payloads == nil {
+		payloads = dictionary.NewInMemoryDictionary(nil)
+	}
+
+	return &indexEntry{
+		index:      nGramIndex,
+		dictionary: dict,
+		weights:    weights,
+		payloads:   payloads,
+		attributes: attributes,
+		refs:       1,
+	}
+}
+
+// buildTextIndexes builds the word and the phonetic indexes of the dictionary, if they are described
+func (e *indexEntry) buildTextIndexes(description IndexDescription) (err error) {
+	if description.WordSearch {
+		if e.words, err = newWordIndex(e.dictionary, description); err != nil {
+			return err
+		}
+	}
+
+	if len(description.Phonetic) > 0 {
+		if e.phonetic, err = newPhoneticIndex(e.dictionary, description); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openTextIndexes builds the word index of the on-disc dictionary and reads the phonetic keys persisted
+// along with the segments of the index in the directory, if they are described
+func (e *indexEntry) openTextIndexes(directory store.Directory, description IndexDescription) (err error) {
+	if description.WordSearch {
+		if e.words, err = newWordIndex(e.dictionary, description); err != nil {
+			return err
+		}
+	}
+
+	if len(description.Phonetic) > 0 {
+		if e.phonetic, err = openPhoneticIndex(directory, description, e.dictionary); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateTextIndexes derives the word and the phonetic indexes from the ones of prev by applying the given changes,
+// so the dictionary is not scanned again. The indexes of prev are left untouched
+func (e *indexEntry) updateTextIndexes(prev *indexEntry, docs []Document, deletes []dictionary.Key) (err error) {
+	if prev.words != nil {
+		if e.words, err = prev.words.update(prev.dictionary, docs, deletes); err != nil {
+			return err
+		}
+	}
+
+	if prev.phonetic != nil {
+		if e.phonetic, err = prev.phonetic.update(prev.dictionary, docs, deletes); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filtered makes the collectors of the given factory receive only the documents,
+// that satisfy the filter
+func (e *indexEntry) filtered(factory CollectorManagerFactory, filter Filter) CollectorManagerFactory {
+	if len(filter) == 0 {
+		return factory
+	}
+
+	return newFilteredCollectorManager(factory, filter.evaluate(e.attributes))
+}
+
+// verified wraps the collector managers of the given factory, so they collect only the candidates,
+// that match the query with the given matching
+func (e *indexEntry) verified(factory CollectorManagerFactory, query string, matching AutocompleteMatching) CollectorManagerFactory {
+	if e.verifier == nil || matching == "" || matching == PrefixMatching {
+		return factory
+	}
+
+	return newVerifiedCollectorManager(factory, e.verifier.verifyInfix(e.dictionary, query, matching))
+}
+
+// analyze returns the analyzed form of the given text, that the typos are counted on
+func (e *indexEntry) analyze(text string) string {
+	if e.verifier == nil {
+		return strings.ToLower(text)
+	}
+
+	return e.verifier.analyze(text)
+}
+
+// suggestWords returns topK documents, which words are similar to the query words,
+// the scores are blended with the document weights if the query has a weight formula
+func (e *indexEntry) suggestWords(ctx context.Context, query string, config SearchConfig, topK int) ([]Candidate, error) {
+	if e.words == nil {
+		return nil, fmt.Errorf("word search is not enabled for the dictionary")
+	}
+
+	var filter *roaring.Bitmap
+
+	if len(config.options.filter) > 0 {
+		filter = config.options.filter.evaluate(e.attributes)
+	}
+
+	scores, err := e.words.Suggest(ctx, query, config.similarity, config.metric, config.options.wordMatching, filter)
+	queue := NewTopKQueue(topK)
+	formula := config.options.weightFormula
+
+	for key, score := range scores {
+		if formula != nil {
+			score = formula(score, e.weights.Get(key), e.weights.Max())
+		}
+
+		queue.Add(key, score)
+	}
+
+	return queue.GetCandidates(), err
+}
+
+// blendPhonetic blends the scores of the given candidates with the phonetic scores of the query and adds
+// the best documents, that sound like the query, but are absent among the candidates. The topK best ones are returned
+func (e *indexEntry) blendPhonetic(query string, candidates []Candidate, config SearchConfig, topK int) ([]Candidate, error) {
+	if e.phonetic == nil {
+		return nil, fmt.Errorf("phonetic matching is not enabled for the dictionary")
+	}
+
+	var filter *roaring.Bitmap
+
+	if len(config.options.filter) > 0 {
+		filter = config.options.filter.evaluate(e.attributes)
+	}
+
+	boost := config.options.phoneticBoost
+	phonetic := e.phonetic.Match(query, candidates, topK*phoneticCandidatesFactor, filter)
+	queue := NewTopKQueue(topK)
+
+	for _, candidate := range candidates {
+		queue.Add(candidate.Key, (1-boost)*candidate.Score+boost*phonetic[candidate.Key])
+		delete(phonetic, candidate.Key)
+	}
+
+	formula := config.options.weightFormula
+
+	for key, score := range phonetic {
+		score *= boost
+
+		if formula != nil {
+			score = formula(score, e.weights.Get(key), e.weights.Max())
+		}
+
+		queue.Add(key, score)
+	}
+
+	return queue.GetCandidates(), nil
+}
+
+// explain attaches the explanation of its score to each result item of the given candidates
+func (e *indexEntry) explain(config SearchConfig, candidates []Candidate, result []ResultItem) error {
+	explainer, ok := e.index.(explainer)
+
+	if !ok {
+		return fmt.Errorf("explanation is not supported by the dictionary")
+	}
+
+	var rescore func(key dictionary.Key) (float64, error)
+
+	if isRescoring(config.metric) {
+		var err error
+
+		if rescore, err = e.rescorer(config.query, config.metric); err != nil {
+			return err
+		}
+	}
+
+	for i := range result {
+		explanation, err := explainer.Explain(config.query, result[i].Value, config.similarity, config.metric)
+
+		if err != nil {
+			return fmt.Errorf("failed to explain the score of %s: %w", result[i].Value, err)
+		}
+
+		if rescore != nil {
+			score, err := rescore(candidates[i].Key)
+
+			if err != nil {
+				return fmt.Errorf("failed to explain the score of %s: %w", result[i].Value, err)
+			}
+
+			explanation.Distance, explanation.MetricScore = 1-score, score
+		}
+
+		if config.options.weightFormula != nil {
+			weight := e.weights.Get(candidates[i].Key)
+			explanation.Weight = &weight
+		}
+
+		result[i].Explanation = &explanation
+	}
+
+	return nil
+}
+
+// resultItems fetches the values and the payloads of the given candidates and highlights the query in the values,
+// the candidate scores are kept only if scored is true. A candidate found by the queries, that differ from
+// the query, i.e. by a keyboard remapping of it, is highlighted with them
+func (e *indexEntry) resultItems(
+	query string,
+	queries map[dictionary.Key][]string,
+	candidates []Candidate,
+	scored bool,
+) ([]ResultItem, error) {
+	result := make([]ResultItem, 0, len(candidates))
+	h := e.highlighter
+
+	if h == nil {
+		h = &highlighter{}
+	}
+
+	for _, candidate := range candidates {
+		value, err := e.dictionary.Get(candidate.Key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		payload, err := e.payloads.Get(candidate.Key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		item := ResultItem{Value: value}
+
+		found, ok := queries[candidate.Key]
+
+		if !ok {
+			found = []string{query}
+		}
+
+		if highlights := h.highlight(found, value); len(highlights) > 0 {
+			item.Highlights = highlights
+		}
+
+		if scored {
+			item.Score = candidate.Score
+		}
+
+		if payload != dictionary.NilValue {
+			item.Payload = json.RawMessage(payload)
+		}
+
+		result = append(result, item)
+	}
+
+	return result, nil
+}
+
+// partialResultItems fetches the result items of the candidates returned by the search with the error searchErr.
+// If the search has been interrupted by the context, the collected candidates are returned along with the error
+func (e *indexEntry) partialResultItems(
+	ctx context.Context,
+	query string,
+	queries map[dictionary.Key][]string,
+	candidates []Candidate,
+	searchErr error,
+	scored bool,
+) ([]ResultItem, error) {
+	if searchErr != nil && ctx.Err() == nil {
+		return nil, searchErr
+	}
+
+	result, err := e.resultItems(query, queries, candidates, scored)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, searchErr
+}
+
+// retain takes a reference on the entry
+func (e *indexEntry) retain() {
+	atomic.AddInt32(&e.refs, 1)
+}
+
+// release drops a reference on the entry and closes it if there are no references left
+func (e *indexEntry) release() {
+	if atomic.AddInt32(&e.refs, -1) == 0 {
+		e.close()
+	}
+}
+
+// close releases the index and its dictionaries
+func (e *indexEntry) close() {
+	if closer, ok := e.index.(io.Closer); ok {
+		_ = closer.Close()
+	}
+
+	_ = closeDictionary(e.dictionary)
+	_ = closeDictionary(e.payloads)
+}
+
+// generation is a snapshot of the search indexes managed by Service.
+// A generation is never modified after it has become current, a change
+// of the indexes creates a new generation instead
+type generation struct {
+	entries      map[string]*indexEntry
+	descriptions map[string]IndexDescription
+	// inFlight tracks the queries that are still served by the generation
+	inFlight sync.WaitGroup
+}
+
+// newGeneration creates an empty generation
+func newGeneration() *generation {
+	return &generation{
+		entries:      make(map[string]*indexEntry),
+		descriptions: make(map[string]IndexDescription),
+	}
+}
+
+// copy returns a new generation with the same indexes, the new generation
+// takes a reference on each of them
+func (g *generation) copy() *generation {
+	next := newGeneration()
+
+	for name, entry := range g.entries {
+		entry.retain()
+		next.entries[name] = entry
+	}
+
+	for name, description := range g.descriptions {
+		next.descriptions[name] = description
+	}
+
+	return next
+}
+
+// set adds the given index with the name to the generation, the reference of the replaced index is dropped
+func (g *generation) set(name string, entry *indexEntry) {
+	if prev, ok := g.entries[name]; ok {
+		prev.release()
+	}
+
+	g.entries[name] = entry
+}
+
+// retire waits until all in-flight queries of the generation are done and drops
+// the references on its indexes. An index shared with other generations is closed
+// only after all of them are retired, so the queries of the older generations can still use it
+func (g *generation) retire() {
+	g.inFlight.Wait()
+	g.release()
+}
+
+// release drops the references of the generation on its indexes
+func (g *generation) release() {
+	for _, entry := range g.entries {
+		entry.release()
+	}
+}
+
+// closeDictionary releases the given dictionary, if it is closable
+func closeDictionary(dict dictionary.Dictionary) error {
+	if closer, ok := dict.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
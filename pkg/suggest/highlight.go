@@ -0,0 +1,222 @@
+package suggest
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+)
+
+// defaultHighlightNGramSize is the n-gram size of the highlighting, if the index has no description
+const defaultHighlightNGramSize = 3
+
+// Highlight is a range of the characters of a result value, that match the query.
+// The offsets are counted in characters (runes), End is exclusive
+type Highlight struct {
+	Start int
+	End   int
+}
+
+// highlighter finds the ranges of a value, that match the query. The query and the value words are compared
+// in the analyzed form of the index, i.e. after its normalization, transliteration and analysis stages,
+// so "mersedes" highlights "Мер" and "едес" of "Мерседес" for an index with the transliteration
+type highlighter struct {
+	nGramSize int
+	stages    []analysis.Stage
+}
+
+// newHighlighter creates a new instance of highlighter for the index of the given description
+func newHighlighter(d IndexDescription) (*highlighter, error) {
+	stages, err := d.analysisStages()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &highlighter{
+		nGramSize: d.NGramSize,
+		stages:    stages,
+	}, nil
+}
+
+// analyzedWord is a word of a value in the analyzed form, origins holds the value offset of each analyzed
+// character. The origins are nil if the analyzed characters can't be traced back, i.e. if the word
+// has been stemmed, then the whole word is highlighted on a match
+type analyzedWord struct {
+	text       []rune
+	origins    []int
+	start, end int
+}
+
+// highlight returns the ranges of the value covered by the n-grams of the words of the given queries,
+// so a fuzzy match is highlighted as well, i.e. "mersedes" highlights "Mer" and "edes" of "Mercedes".
+// A query word shorter than the n-gram size is matched as a whole
+func (h *highlighter) highlight(queries []string, value string) []Highlight {
+	nGramSize := h.nGramSize
+
+	if nGramSize <= 0 {
+		nGramSize = defaultHighlightNGramSize
+	}
+
+	runes := []rune(value)
+	covered := make([]bool, len(runes))
+	words := make([]analyzedWord, 0)
+
+	for _, bounds := range splitWords(runes) {
+		words = append(words, h.analyzeWord(runes, bounds[0], bounds[1]))
+	}
+
+	for _, query := range queries {
+		queryRunes := []rune(query)
+
+		for _, bounds := range splitWords(queryRunes) {
+			word := []rune(h.analyze(string(queryRunes[bounds[0]:bounds[1]])))
+			size := nGramSize
+
+			if len(word) < size {
+				size = len(word)
+			}
+
+			for i := 0; size > 0 && i+size <= len(word); i++ {
+				for _, valueWord := range words {
+					markOccurrences(valueWord, word[i:i+size], covered)
+				}
+			}
+		}
+	}
+
+	highlights := []Highlight{}
+
+	for i := 0; i < len(covered); i++ {
+		if !covered[i] {
+			continue
+		}
+
+		start := i
+
+		for i < len(covered) && covered[i] {
+			i++
+		}
+
+		highlights = append(highlights, Highlight{Start: start, End: i})
+	}
+
+	return highlights
+}
+
+// analyze returns the analyzed form of the given text
+func (h *highlighter) analyze(text string) string {
+	if len(h.stages) == 0 {
+		return strings.ToLower(text)
+	}
+
+	list := []analysis.Token{text}
+
+	for _, stage := range h.stages {
+		list = stage(list)
+	}
+
+	return strings.Join(list, "")
+}
+
+// analyzeWord returns the analyzed form of the word of the value between the start and the end offsets.
+// Each character is analyzed separately to trace the analyzed characters back to the value ones,
+// this is correct as long as the separately analyzed characters make up the analyzed word
+func (h *highlighter) analyzeWord(runes []rune, start, end int) analyzedWord {
+	word := analyzedWord{
+		text:  []rune(h.analyze(string(runes[start:end]))),
+		start: start,
+		end:   end,
+	}
+
+	chars := make([]rune, 0, len(word.text))
+	origins := make([]int, 0, len(word.text))
+
+	for i := start; i < end; i++ {
+		for _, r := range h.analyze(string(runes[i])) {
+			chars = append(chars, r)
+			origins = append(origins, i)
+		}
+	}
+
+	if len(chars) >= len(word.text) && string(chars[:len(word.text)]) == string(word.text) {
+		word.origins = origins[:len(word.text)]
+	}
+
+	return word
+}
+
+// splitWords returns the start and the end offsets of the words of the text, a word is a sequence
+// of letters, digits and combining marks
+func splitWords(runes []rune) [][2]int {
+	words := [][2]int{}
+
+	for i := 0; i < len(runes); i++ {
+		if !isWordRune(runes[i]) {
+			continue
+		}
+
+		start := i
+
+		for i < len(runes) && isWordRune(runes[i]) {
+			i++
+		}
+
+		words = append(words, [2]int{start, i})
+	}
+
+	return words
+}
+
+// isWordRune tells whether the character is a part of a word
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+// markOccurrences marks the value characters of each occurrence of the n-gram in the word as covered
+func markOccurrences(word analyzedWord, nGram []rune, covered []bool) {
+	for i := 0; i+len(nGram) <= len(word.text); i++ {
+		match := true
+
+		for j, r := range nGram {
+			if word.text[i+j] != r {
+				match = false
+				break
+			}
+		}
+
+		if !match {
+			continue
+		}
+
+		if word.origins == nil {
+			for j := word.start; j < word.end; j++ {
+				covered[j] = true
+			}
+
+			continue
+		}
+
+		for j := range nGram {
+			covered[word.origins[i+j]] = true
+		}
+	}
+}
+
+// recordQueries wraps the search function, so it records the queries, that have found each candidate.
+// The remapped queries of the keyboard layouts find the candidates the query itself doesn't match,
+// so the candidates are highlighted with the queries, that have found them
+func recordQueries(search func(query string) ([]Candidate, error)) (func(query string) ([]Candidate, error), map[dictionary.Key][]string) {
+	queries := map[dictionary.Key][]string{}
+
+	return func(query string) ([]Candidate, error) {
+		candidates, err := search(query)
+
+		for _, candidate := range candidates {
+			queries[candidate.Key] = append(queries[candidate.Key], query)
+		}
+
+		return candidates, err
+	}, queries
+}
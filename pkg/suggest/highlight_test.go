@@ -0,0 +1,78 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/analysis"
+)
+
+func TestHighlight(t *testing.T) {
+	testCases := []struct {
+		query, value string
+		expected     []Highlight
+	}{
+		{"mersedes", "Mercedes Benz", []Highlight{{0, 3}, {4, 8}}},
+		{"x5 bmw", "BMW X5", []Highlight{{0, 3}, {4, 6}}},
+		{"мазд", "Мазда 6", []Highlight{{0, 4}}},
+		{"golf", "Volkswagen Golf", []Highlight{{11, 15}}},
+		{"audi", "BMW X5", []Highlight{}},
+	}
+
+	h := &highlighter{nGramSize: 3}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, h.highlight([]string{testCase.query}, testCase.value), testCase.query)
+	}
+}
+
+func TestHighlightAnalyzed(t *testing.T) {
+	transliterated, err := newHighlighter(IndexDescription{NGramSize: 3, Transliteration: "informal"})
+	assert.NoError(t, err)
+
+	normalized, err := newHighlighter(IndexDescription{
+		NGramSize: 3,
+		Normalization: &analysis.UnicodeNormalization{
+			CaseFolding:     true,
+			StripDiacritics: true,
+		},
+	})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		highlighter  *highlighter
+		query, value string
+		expected     []Highlight
+	}{
+		{transliterated, "mersedes", "Мерседес Бенц", []Highlight{{0, 8}}},
+		// "ж" is transliterated to "zh", both characters are traced back to it
+		{transliterated, "zhig", "Жигули", []Highlight{{0, 3}}},
+		// "ц" is transliterated to "ts", so it differs from "z"
+		{transliterated, "benz", "Mercedes-Бенц", []Highlight{{9, 12}}},
+		{normalized, "skoda", "Škoda Octavia", []Highlight{{0, 5}}},
+		{normalized, "citroen", "Citroën C4", []Highlight{{0, 7}}},
+	}
+
+	for _, testCase := range testCases {
+		actual := testCase.highlighter.highlight([]string{testCase.query}, testCase.value)
+		assert.Equal(t, testCase.expected, actual, testCase.query)
+	}
+}
+
+func TestHighlightRemapped(t *testing.T) {
+	service, description := newTestService(t, "BMW X5\nМазда 6\n", nil)
+
+	layouts := WithKeyboardLayouts(0.5, analysis.JcukenToQwertyLayout, analysis.QwertyToJcukenLayout)
+
+	// the results are highlighted with the remapped queries, that have found them
+	result, err := service.Autocomplete(context.Background(), description.Name, "иьц", 5, layouts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BMW X5"}, resultValues(result))
+	assert.Equal(t, []Highlight{{0, 3}}, result[0].Highlights)
+
+	result, err = service.Autocomplete(context.Background(), description.Name, "vfp", 5, layouts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Мазда 6"}, resultValues(result))
+	assert.Equal(t, []Highlight{{0, 3}}, result[0].Highlights)
+}
@@ -1,8 +1,12 @@
 package suggest
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 
+	"github.com/RoaringBitmap/roaring"
 	"github.com/suggest-go/suggest/pkg/analysis"
 	"github.com/suggest-go/suggest/pkg/dictionary"
 	"github.com/suggest-go/suggest/pkg/index"
@@ -43,3 +47,528 @@ func Index(
 
 	return nil
 }
+
+// Document is an entry of a dictionary, that can be added to a search index at runtime
+type Document struct {
+	// Key is a unique identifier of the document in the dictionary
+	Key dictionary.Key
+	// Value is a string value of the document
+	Value dictionary.Value
+	// Weight is a popularity weight of the document, 0 means that the document has no weight
+	Weight float64
+	// Payload is an optional JSON object stored along with the document
+	Payload json.RawMessage
+	// Attributes are the values of the filterable document attributes
+	Attributes map[string][]string
+}
+
+// BuildDictionary builds the base dictionary, the document weights, the document attributes,
+// the phonetic keys of the document words and the document payloads of the on-disc index
+// from the source of the given description
+func BuildDictionary(directory store.Directory, description IndexDescription) (dictionary.Dictionary, error) {
+	weights := make(map[dictionary.Key]float64)
+	attributes := Attributes{}
+	phonetic, err := newEmptyPhoneticIndex(description)
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := &sourceDictionary{
+		description: description,
+		value: func(doc Document) (dictionary.Value, bool) {
+			if doc.Weight > 0 {
+				weights[doc.Key] = doc.Weight
+			}
+
+			attributes.add(doc.Key, doc.Attributes)
+
+			if len(phonetic.encoders) > 0 {
+				phonetic.add(doc.Key, doc.Value)
+			}
+
+			return doc.Value, true
+		},
+	}
+
+	dict, err := dictionary.BuildCDBDictionary(values, description.GetDictionaryFile())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build a dictionary: %w", err)
+	}
+
+	if err := writeWeights(directory, weightsFileName(description.Name), weights); err != nil {
+		return nil, err
+	}
+
+	if err := writeAttributes(directory, attributesFileName(description.Name), attributes); err != nil {
+		return nil, err
+	}
+
+	if err := writeSegmentPhoneticKeys(directory, phoneticFileName(description.Name), phonetic); err != nil {
+		return nil, err
+	}
+
+	if description.Format != JSONLinesFormat {
+		if err := os.Remove(description.GetPayloadFile()); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale payloads: %w", err)
+		}
+
+		return dict, nil
+	}
+
+	payloads := &sourceDictionary{
+		description: description,
+		value: func(doc Document) (dictionary.Value, bool) {
+			return dictionary.Value(doc.Payload), len(doc.Payload) > 0
+		},
+	}
+
+	if _, err := dictionary.BuildCDBDictionary(payloads, description.GetPayloadFile()); err != nil {
+		return nil, fmt.Errorf("failed to build payloads: %w", err)
+	}
+
+	return dict, nil
+}
+
+// UpdateIndex persists the given changes of the on-disc search index as a new segment.
+// The documents with already existing keys are replaced, the deletes keys are removed from the index
+func UpdateIndex(
+	directory store.Directory,
+	description IndexDescription,
+	docs []Document,
+	deletes []dictionary.Key,
+) error {
+	lock, err := LockIndex(directory, description)
+
+	if err != nil {
+		return err
+	}
+
+	defer lock.Close()
+
+	return updateIndex(directory, description, docs, deletes)
+}
+
+// updateIndex persists the given changes of the on-disc search index as a new segment,
+// the caller should hold the lock of the index
+func updateIndex(
+	directory store.Directory,
+	description IndexDescription,
+	docs []Document,
+	deletes []dictionary.Key,
+) error {
+	if err := description.validate(); err != nil {
+		return fmt.Errorf("invalid index description: %w", err)
+	}
+
+	tokenizer, err := OpenIndexTokenizer(directory, description)
+
+	if err != nil {
+		return err
+	}
+
+	encoder, err := index.NewEncoder()
+
+	if err != nil {
+		return fmt.Errorf("failed to create Encoder: %w", err)
+	}
+
+	writer, err := index.NewSegmentWriter(directory, description.Name, encoder)
+
+	if err != nil {
+		return fmt.Errorf("failed to create SegmentWriter: %w", err)
+	}
+
+	for _, key := range deletes {
+		if err := writer.DeleteDocument(key); err != nil {
+			return err
+		}
+	}
+
+	docs = uniqueDocuments(docs)
+
+	for _, doc := range docs {
+		if err := writer.AddDocument(doc.Key, tokenizer.Tokenize(doc.Value)); err != nil {
+			return err
+		}
+	}
+
+	// the segment dictionary should be persisted before the segment becomes visible
+	if len(docs) > 0 {
+		_, err := dictionary.BuildCDBDictionary(
+			documentList(docs),
+			description.GetSegmentDictionaryFile(writer.SegmentName()),
+		)
+
+		if err != nil {
+			return fmt.Errorf("failed to build a segment dictionary: %w", err)
+		}
+
+		if err := writeWeights(directory, weightsFileName(writer.SegmentName()), documentWeights(docs)); err != nil {
+			return err
+		}
+
+		if err := writeAttributes(directory, attributesFileName(writer.SegmentName()), NewAttributes(docs)); err != nil {
+			return err
+		}
+
+		if len(description.Phonetic) > 0 {
+			phonetic, err := newPhoneticIndex(documentList(docs), description)
+
+			if err != nil {
+				return err
+			}
+
+			if err := writeSegmentPhoneticKeys(directory, phoneticFileName(writer.SegmentName()), phonetic); err != nil {
+				return err
+			}
+		}
+
+		if payloads := documentPayloads(docs); len(payloads) > 0 {
+			_, err := dictionary.BuildCDBDictionary(payloads, description.GetSegmentPayloadFile(writer.SegmentName()))
+
+			if err != nil {
+				return fmt.Errorf("failed to build segment payloads: %w", err)
+			}
+		}
+	}
+
+	if err := writer.Commit(); err != nil {
+		return fmt.Errorf("failed to commit a segment: %w", err)
+	}
+
+	return nil
+}
+
+// sourceDictionary is an adapter, that implements dictionary.Iterable for the source of the description,
+// value tells which value of the document should be iterated and whether the document should be skipped
+type sourceDictionary struct {
+	description IndexDescription
+	value       func(doc Document) (dictionary.Value, bool)
+}
+
+// Iterate iterates through each document of the source
+func (s *sourceDictionary) Iterate(iterator dictionary.Iterator) error {
+	reader, closer, err := OpenSource(s.description)
+
+	if err != nil {
+		return err
+	}
+
+	defer closer.Close()
+
+	return reader.Iterate(func(doc Document) error {
+		value, ok := s.value(doc)
+
+		if !ok {
+			return nil
+		}
+
+		return iterator(doc.Key, value)
+	})
+}
+
+// documentList is an adapter, that implements dictionary.Iterable for a list of documents
+type documentList []Document
+
+// Iterate iterates through each document of the list
+func (l documentList) Iterate(iterator dictionary.Iterator) error {
+	for _, doc := range l {
+		if err := iterator(doc.Key, doc.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// documentWeights returns the weights of the weighted documents of the given list
+func documentWeights(docs []Document) map[dictionary.Key]float64 {
+	weights := make(map[dictionary.Key]float64)
+
+	for _, doc := range docs {
+		if doc.Weight > 0 {
+			weights[doc.Key] = doc.Weight
+		}
+	}
+
+	return weights
+}
+
+// documentPayloads returns the payloads of the given list of documents
+func documentPayloads(docs []Document) payloadList {
+	payloads := make(payloadList, 0)
+
+	for _, doc := range docs {
+		if len(doc.Payload) > 0 {
+			payloads = append(payloads, doc)
+		}
+	}
+
+	return payloads
+}
+
+// payloadList is an adapter, that implements dictionary.Iterable for the payloads of a list of documents
+type payloadList []Document
+
+// Iterate iterates through the payload of each document of the list
+func (l payloadList) Iterate(iterator dictionary.Iterator) error {
+	for _, doc := range l {
+		if err := iterator(doc.Key, dictionary.Value(doc.Payload)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// uniqueDocuments returns the list of documents where only the last document with the same key is kept
+func uniqueDocuments(docs []Document) []Document {
+	positions := make(map[dictionary.Key]int, len(docs))
+	unique := make([]Document, 0, len(docs))
+
+	for _, doc := range docs {
+		if i, ok := positions[doc.Key]; ok {
+			unique[i] = doc
+			continue
+		}
+
+		positions[doc.Key] = len(unique)
+		unique = append(unique, doc)
+	}
+
+	return unique
+}
+
+// Compact merges all segments of the on-disc search index into a single one.
+// Deleted documents are dropped and the rest documents are renumbered, so the keys of
+// the dictionary become dense: the new key of a document is the number of the live documents
+// with lesser keys. So the keys known before the compaction, i.e. the ones passed to
+// Service.UpdateDocuments, no longer refer to the same documents, the callers keeping them
+// should remap them the same way or read them again from the compacted dictionary.
+// The compacted index replaces the previous one atomically.
+// The index is locked for the whole compaction, so the updates wait until it is done
+func Compact(directory store.Directory, description IndexDescription) error {
+	lock, err := LockIndex(directory, description)
+
+	if err != nil {
+		return err
+	}
+
+	defer lock.Close()
+
+	dict, err := OpenSegmentedDictionary(directory, description)
+
+	if err != nil {
+		return fmt.Errorf("failed to open a dictionary: %w", err)
+	}
+
+	defer closeDictionary(dict)
+
+	live := roaring.New()
+
+	err = dict.Iterate(func(key dictionary.Key, value dictionary.Value) error {
+		live.Add(key)
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to iterate through a dictionary: %w", err)
+	}
+
+	encoder, err := index.NewEncoder()
+
+	if err != nil {
+		return fmt.Errorf("failed to create Encoder: %w", err)
+	}
+
+	merger, err := index.NewMerger(directory, description.Name, encoder)
+
+	if err != nil {
+		return fmt.Errorf("failed to create Merger: %w", err)
+	}
+
+	// the dictionary keys are renumbered in the same way as the merger does it
+	_, err = dictionary.BuildCDBDictionary(
+		&renumberedDictionary{dict: dict, live: live},
+		description.GetSegmentDictionaryFile(merger.SegmentName()),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to build a compacted dictionary: %w", err)
+	}
+
+	weights, err := OpenWeights(directory, description)
+
+	if err != nil {
+		return fmt.Errorf("failed to open weights: %w", err)
+	}
+
+	if err := writeWeights(directory, weightsFileName(merger.SegmentName()), renumberWeights(weights, live)); err != nil {
+		return err
+	}
+
+	attributes, err := OpenAttributes(directory, description)
+
+	if err != nil {
+		return fmt.Errorf("failed to open attributes: %w", err)
+	}
+
+	if err := writeAttributes(directory, attributesFileName(merger.SegmentName()), attributes.renumber(live)); err != nil {
+		return err
+	}
+
+	if len(description.Phonetic) > 0 {
+		phonetic, err := openPhoneticIndex(directory, description, dict)
+
+		if err != nil {
+			return fmt.Errorf("failed to open phonetic keys: %w", err)
+		}
+
+		err = writePhoneticKeys(directory, phoneticFileName(merger.SegmentName()), phonetic.names, phonetic.keys().renumber(live))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	payloads, err := OpenSegmentedPayloads(directory, description)
+
+	if err != nil {
+		return fmt.Errorf("failed to open payloads: %w", err)
+	}
+
+	defer closeDictionary(payloads)
+
+	_, err = dictionary.BuildCDBDictionary(
+		&renumberedDictionary{dict: payloads, live: live},
+		description.GetSegmentPayloadFile(merger.SegmentName()),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to build compacted payloads: %w", err)
+	}
+
+	if err := merger.Merge(live); err != nil {
+		return fmt.Errorf("failed to merge segments: %w", err)
+	}
+
+	replaced, err := merger.Commit()
+
+	if err != nil {
+		return fmt.Errorf("failed to commit the compacted segment: %w", err)
+	}
+
+	return RemoveSegmentFiles(directory, description, replaced)
+}
+
+// LockIndex obtains the write lock of the index, that guards the segments against concurrent writers,
+// such as the service applying updates and the compaction run by another process.
+// The lock is released on Close, it is a no-op for the directories that are not able to be locked
+func LockIndex(directory store.Directory, description IndexDescription) (io.Closer, error) {
+	locker, ok := directory.(store.Locker)
+
+	if !ok {
+		return nopCloser{}, nil
+	}
+
+	lock, err := locker.Lock(fmt.Sprintf("%s.lock", description.Name))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock the index %s: %w", description.Name, err)
+	}
+
+	return lock, nil
+}
+
+// nopCloser is a Closer that does nothing
+type nopCloser struct{}
+
+// Close does nothing
+func (nopCloser) Close() error {
+	return nil
+}
+
+// RemoveSegmentFiles removes the dictionaries, the payloads, the weights, the attributes and the phonetic keys
+// of the given segments, that are no longer a part of the on-disc index
+func RemoveSegmentFiles(directory store.Directory, description IndexDescription, segments []index.SegmentInfo) error {
+	for _, segment := range segments {
+		if err := os.Remove(description.GetSegmentDictionaryFile(segment.Name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove a segment dictionary: %w", err)
+		}
+
+		if err := os.Remove(description.GetSegmentPayloadFile(segment.Name)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove segment payloads: %w", err)
+		}
+
+		if directory.Exists(weightsFileName(segment.Name)) {
+			if err := directory.Remove(weightsFileName(segment.Name)); err != nil {
+				return fmt.Errorf("failed to remove segment weights: %w", err)
+			}
+		}
+
+		if directory.Exists(attributesFileName(segment.Name)) {
+			if err := directory.Remove(attributesFileName(segment.Name)); err != nil {
+				return fmt.Errorf("failed to remove segment attributes: %w", err)
+			}
+		}
+
+		if directory.Exists(phoneticFileName(segment.Name)) {
+			if err := directory.Remove(phoneticFileName(segment.Name)); err != nil {
+				return fmt.Errorf("failed to remove segment phonetic keys: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// renumberWeights assigns the weights of the live documents to their renumbered keys
+func renumberWeights(weights Weights, live *roaring.Bitmap) map[dictionary.Key]float64 {
+	renumbered := make(map[dictionary.Key]float64)
+	newKey := dictionary.Key(0)
+	it := live.Iterator()
+
+	for it.HasNext() {
+		if weight := weights.Get(it.Next()); weight > 0 {
+			renumbered[newKey] = weight
+		}
+
+		newKey++
+	}
+
+	return renumbered
+}
+
+// renumberedDictionary is an adapter, that iterates through the live documents of the dictionary
+// in the ascending order of their keys and assigns them dense keys. The documents, that are absent
+// in the dictionary, are skipped, but their keys are still reserved
+type renumberedDictionary struct {
+	dict dictionary.Dictionary
+	live *roaring.Bitmap
+}
+
+// Iterate walks through each live document of the dictionary
+func (r *renumberedDictionary) Iterate(iterator dictionary.Iterator) error {
+	newKey := dictionary.Key(0)
+	it := r.live.Iterator()
+
+	for ; it.HasNext(); newKey++ {
+		value, err := r.dict.Get(it.Next())
+
+		if err != nil {
+			return err
+		}
+
+		if value == dictionary.NilValue {
+			continue
+		}
+
+		if err := iterator(newKey, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package suggest
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+func TestUniqueDocuments(t *testing.T) {
+	docs := uniqueDocuments([]Document{
+		{Key: 1, Value: "BMW X5"},
+		{Key: 2, Value: "AUDI Q5"},
+		{Key: 1, Value: "BMW X6"},
+	})
+
+	assert.Equal(t, []Document{{Key: 1, Value: "BMW X6"}, {Key: 2, Value: "AUDI Q5"}}, docs)
+}
+
+func TestRenumberedDictionary(t *testing.T) {
+	dict := dictionary.NewInMemoryDictionary([]string{"BMW X5", "BMW X6", "AUDI Q5", "AUDI Q7"})
+	live := roaring.BitmapOf(1, 3, 10)
+	values := map[dictionary.Key]dictionary.Value{}
+
+	err := (&renumberedDictionary{dict: dict, live: live}).Iterate(func(key dictionary.Key, value dictionary.Value) error {
+		values[key] = value
+		return nil
+	})
+	assert.NoError(t, err)
+
+	// the absent document 10 is skipped
+	assert.Equal(t, map[dictionary.Key]dictionary.Value{0: "BMW X6", 1: "AUDI Q7"}, values)
+
+	weights := renumberWeights(NewWeights(map[dictionary.Key]float64{0: 5, 3: 7, 10: 2}), live)
+	assert.Equal(t, map[dictionary.Key]float64{1: 7, 2: 2}, weights)
+}
+
+func TestCompactOnDiscIndex(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	description := copyOnDiscIndex(t, descriptions[0])
+	defer os.RemoveAll(description.OutputPath)
+
+	service := NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+
+	size := service.current.entries[description.Name].dictionary.Size()
+
+	assert.NoError(t, service.UpdateDocuments(description.Name, []Document{{Key: 100000, Value: "LADA VESTA", Weight: 7}}))
+	assert.NoError(t, service.DeleteDocuments(description.Name, []dictionary.Key{0, 1}))
+
+	directory, err := store.NewFSDirectory(description.GetIndexPath())
+	assert.NoError(t, err)
+	assert.NoError(t, Compact(directory, description))
+
+	infos, err := index.ReadSegmentInfos(directory, description.Name)
+	assert.NoError(t, err)
+	assert.Len(t, infos.Segments, 1)
+
+	service = NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+
+	dict := service.current.entries[description.Name].dictionary
+	assert.Equal(t, size-1, dict.Size())
+
+	value, err := dict.Get(dictionary.Key(size - 2))
+	assert.NoError(t, err)
+	assert.Equal(t, "LADA VESTA", value)
+
+	weights, err := OpenWeights(directory, description)
+	assert.NoError(t, err)
+	assert.Equal(t, 7.0, weights.Get(dictionary.Key(size-2)))
+
+	searchConf, err := NewSearchConfig("Lada Vesta", 5, metric.CosineMetric(), 0.7)
+	assert.NoError(t, err)
+
+	result, err := service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []ResultItem{{Score: 1, Value: "LADA VESTA", Highlights: []Highlight{{0, 4}, {5, 10}}}}, result)
+}
+
+func TestUpdateWaitsForIndexLock(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	description := copyOnDiscIndex(t, descriptions[0])
+	defer os.RemoveAll(description.OutputPath)
+
+	service := NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+
+	directory, err := store.NewFSDirectory(description.GetIndexPath())
+	assert.NoError(t, err)
+
+	lock, err := LockIndex(directory, description)
+	assert.NoError(t, err)
+
+	updated := make(chan error)
+
+	go func() {
+		updated <- service.UpdateDocuments(description.Name, []Document{{Key: 100000, Value: "LADA VESTA"}})
+	}()
+
+	select {
+	case <-updated:
+		t.Fatal("the update should wait until the index lock is released")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	assert.NoError(t, lock.Close())
+	assert.NoError(t, <-updated)
+}
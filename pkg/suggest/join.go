@@ -0,0 +1,270 @@
+package suggest
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+// JoinConfig describes how the strings are matched by a Joiner
+type JoinConfig struct {
+	// TopK is the maximum number of the matches of a string
+	TopK int
+	// Similarity is the minimal similarity of a match
+	Similarity float64
+	// Metric is the similarity metric of the matched strings
+	Metric metric.Metric
+}
+
+// validate checks the consistency of the config
+func (c JoinConfig) validate() error {
+	if c.TopK <= 0 {
+		return errors.New("topK should be greater or equal to 1")
+	}
+
+	if c.Similarity <= 0 || c.Similarity > 1 {
+		return errors.New("similarity should be in (0.0, 1.0]")
+	}
+
+	if c.Metric == nil {
+		return errors.New("metric should be set")
+	}
+
+	return nil
+}
+
+// JoinPair is a pair of the similar strings found by a Joiner
+type JoinPair struct {
+	// LeftKey is the line number of the left string, starting from 0
+	LeftKey dictionary.Key
+	// RightKey is the key of the right string in the dictionary of the Joiner
+	RightKey dictionary.Key
+	// Left is the left string
+	Left string
+	// Right is the right string
+	Right string
+	// Score is the similarity of the strings
+	Score float64
+}
+
+// Joiner performs a fuzzy join of strings with a dictionary: each string is matched with
+// the similar strings of the dictionary, i.e. to resolve the entities of one list in another one
+type Joiner struct {
+	dictionary dictionary.Dictionary
+	index      NGramIndex
+	config     JoinConfig
+}
+
+// NewJoiner builds a RAM index over the given dictionary by the description
+// and returns a Joiner, that matches strings with the documents of the dictionary
+func NewJoiner(dict dictionary.Dictionary, description IndexDescription, config JoinConfig) (*Joiner, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid join config: %w", err)
+	}
+
+	builder, err := NewRAMBuilder(dict, description)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RAMDriver builder: %w", err)
+	}
+
+	nGramIndex, err := builder.Build()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build NGramIndex: %w", err)
+	}
+
+	return &Joiner{
+		dictionary: dict,
+		index:      nGramIndex,
+		config:     config,
+	}, nil
+}
+
+// Join streams the lines of the reader through the index and calls emit for each pair of a line
+// and a similar document of the dictionary. The pairs of a line are emitted in descending order of their scores
+func (j *Joiner) Join(ctx context.Context, reader io.Reader, emit func(pair JoinPair) error) error {
+	scanner := bufio.NewScanner(reader)
+
+	for key := dictionary.Key(0); scanner.Scan(); key++ {
+		pairs, err := j.match(ctx, key, scanner.Text(), j.config.TopK)
+
+		if err != nil {
+			return err
+		}
+
+		for _, pair := range pairs {
+			if err := emit(pair); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read strings: %w", err)
+	}
+
+	return nil
+}
+
+// Deduplicate joins the dictionary with itself and clusters its near-duplicates: two documents
+// fall into the same cluster if they are linked by a chain of the similar documents.
+// Returns the clusters of at least two documents, the keys of each cluster are sorted
+// and the clusters are ordered by their first keys
+func (j *Joiner) Deduplicate(ctx context.Context) ([][]dictionary.Key, error) {
+	set := newDisjointSet()
+
+	err := j.dictionary.Iterate(func(key dictionary.Key, value dictionary.Value) error {
+		// the document matches itself, so one more candidate is requested
+		pairs, err := j.match(ctx, key, value, j.config.TopK+1)
+
+		if err != nil {
+			return err
+		}
+
+		for _, pair := range pairs {
+			if pair.RightKey != key {
+				set.union(key, pair.RightKey)
+			}
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to deduplicate dictionary: %w", err)
+	}
+
+	return set.clusters(), nil
+}
+
+// match returns the pairs of the given string with at most topK similar documents of the dictionary
+func (j *Joiner) match(ctx context.Context, key dictionary.Key, value string, topK int) ([]JoinPair, error) {
+	collectLimit := topK
+	rescoring := isRescoring(j.config.Metric)
+
+	if rescoring {
+		collectLimit = topK * rescoreCandidatesFactor
+	}
+
+	candidates, err := j.index.Suggest(ctx, value, j.config.Similarity, j.config.Metric, newFuzzyCollectorManager(collectLimit))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to match %s: %w", value, err)
+	}
+
+	if rescoring {
+		score, err := newKeyRescorer(j.index, j.dictionary, value, j.config.Metric)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if candidates, err = rescoreCandidates(candidates, score, j.config.Similarity, nil, topK); err != nil {
+			return nil, fmt.Errorf("failed to rescore the matches of %s: %w", value, err)
+		}
+	}
+
+	pairs := make([]JoinPair, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		right, err := j.dictionary.Get(candidate.Key)
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to get document %d: %w", candidate.Key, err)
+		}
+
+		pairs = append(pairs, JoinPair{
+			LeftKey:  key,
+			RightKey: candidate.Key,
+			Left:     value,
+			Right:    right,
+			Score:    candidate.Score,
+		})
+	}
+
+	return pairs, nil
+}
+
+// disjointSet is a union-find of the dictionary keys
+type disjointSet struct {
+	parent map[dictionary.Key]dictionary.Key
+	size   map[dictionary.Key]int
+}
+
+// newDisjointSet creates an empty disjointSet
+func newDisjointSet() *disjointSet {
+	return &disjointSet{
+		parent: map[dictionary.Key]dictionary.Key{},
+		size:   map[dictionary.Key]int{},
+	}
+}
+
+// find returns the root of the set of the given key, the path to the root is compressed
+func (s *disjointSet) find(key dictionary.Key) dictionary.Key {
+	parent, ok := s.parent[key]
+
+	if !ok {
+		s.parent[key] = key
+		s.size[key] = 1
+
+		return key
+	}
+
+	if parent == key {
+		return key
+	}
+
+	root := s.find(parent)
+	s.parent[key] = root
+
+	return root
+}
+
+// union merges the sets of the given keys, the smaller set is attached to the larger one
+func (s *disjointSet) union(a, b dictionary.Key) {
+	a, b = s.find(a), s.find(b)
+
+	if a == b {
+		return
+	}
+
+	if s.size[a] < s.size[b] {
+		a, b = b, a
+	}
+
+	s.parent[b] = a
+	s.size[a] += s.size[b]
+}
+
+// clusters returns the sets of at least two keys, the keys of each set are sorted
+// and the sets are ordered by their first keys
+func (s *disjointSet) clusters() [][]dictionary.Key {
+	groups := map[dictionary.Key][]dictionary.Key{}
+
+	for key := range s.parent {
+		root := s.find(key)
+		groups[root] = append(groups[root], key)
+	}
+
+	clusters := make([][]dictionary.Key, 0, len(groups))
+
+	for _, keys := range groups {
+		if len(keys) < 2 {
+			continue
+		}
+
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		clusters = append(clusters, keys)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool { return clusters[i][0] < clusters[j][0] })
+
+	return clusters
+}
@@ -0,0 +1,107 @@
+package suggest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestJoin(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	dict := dictionary.NewInMemoryDictionary([]string{
+		"Nissan March",
+		"Nissan Micra",
+		"Toyota Corolla",
+		"Honda Fit",
+	})
+
+	config := JoinConfig{
+		TopK:       2,
+		Similarity: 0.5,
+		Metric:     metric.CosineMetric(),
+	}
+
+	joiner, err := NewJoiner(dict, descriptions[0], config)
+	assert.NoError(t, err)
+
+	actual := []string{}
+	err = joiner.Join(context.Background(), strings.NewReader("nissan march\ntayota corola\nvolkswagen\nhonda fitt\n"), func(pair JoinPair) error {
+		actual = append(actual, pair.Left+" -> "+pair.Right)
+		assert.True(t, pair.Score >= config.Similarity)
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"nissan march -> Nissan March",
+		"nissan march -> Nissan Micra",
+		"tayota corola -> Toyota Corolla",
+		"honda fitt -> Honda Fit",
+	}, actual)
+
+	// the matches of a re-scoring metric are scored by it
+	config.Metric = metric.JaroWinklerMetric()
+	joiner, err = NewJoiner(dict, descriptions[0], config)
+	assert.NoError(t, err)
+
+	pairs := []JoinPair{}
+	err = joiner.Join(context.Background(), strings.NewReader("nissan march\n"), func(pair JoinPair) error {
+		pairs = append(pairs, pair)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, pairs, 2)
+	assert.Equal(t, "Nissan March", pairs[0].Right)
+	assert.InDelta(t, 1.0, pairs[0].Score, 1e-9)
+	assert.InDelta(t, metric.JaroWinklerSimilarity("nissan march", "nissan micra"), pairs[1].Score, 1e-9)
+
+	_, err = NewJoiner(dict, descriptions[0], JoinConfig{TopK: 1, Similarity: 0.5})
+	assert.Error(t, err)
+}
+
+func TestDeduplicate(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	dict := dictionary.NewInMemoryDictionary([]string{
+		"Toyota Corolla",
+		"Nissan March",
+		"Toyota Corola",
+		"Honda Fit",
+		"Tayota Corola",
+		"Nissan March ",
+		"Volkswagen Golf",
+	})
+
+	joiner, err := NewJoiner(dict, descriptions[0], JoinConfig{
+		TopK:       5,
+		Similarity: 0.6,
+		Metric:     metric.CosineMetric(),
+	})
+	assert.NoError(t, err)
+
+	clusters, err := joiner.Deduplicate(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, [][]dictionary.Key{{0, 2, 4}, {1, 5}}, clusters)
+}
+
+func TestDisjointSet(t *testing.T) {
+	set := newDisjointSet()
+
+	set.union(5, 3)
+	set.union(1, 2)
+	set.union(3, 1)
+	set.union(7, 8)
+	set.union(8, 7)
+	set.find(9)
+
+	assert.Equal(t, [][]dictionary.Key{{1, 2, 3, 5}, {7, 8}}, set.clusters())
+}
@@ -1,6 +1,13 @@
 package suggest
 
-import "github.com/suggest-go/suggest/pkg/metric"
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
 
 // NGramIndex is the interface that provides the access to
 // approximate string search and autocomplete
@@ -20,14 +27,91 @@ func NewNGramIndex(suggester Suggester, autocomplete Autocomplete) NGramIndex {
 type nGramIndex struct {
 	suggester    Suggester
 	autocomplete Autocomplete
+	// infix holds the autocompletes, that match the query in the middle of a candidate
+	infix   map[AutocompleteMatching]Autocomplete
+	indices index.InvertedIndexIndices
 }
 
 // Suggest returns top-k similar candidates
-func (n *nGramIndex) Suggest(query string, similarity float64, metric metric.Metric, factory CollectorManagerFactory) ([]Candidate, error) {
-	return n.suggester.Suggest(query, similarity, metric, factory)
+func (n *nGramIndex) Suggest(
+	ctx context.Context,
+	query string,
+	similarity float64,
+	metric metric.Metric,
+	factory CollectorManagerFactory,
+) ([]Candidate, error) {
+	return n.suggester.Suggest(ctx, query, similarity, metric, factory)
+}
+
+// Explain returns the explanation of the Suggest score of the candidate with the given value
+func (n *nGramIndex) Explain(query, value string, similarity float64, metric metric.Metric) (Explanation, error) {
+	e, ok := n.suggester.(explainer)
+
+	if !ok {
+		return Explanation{}, fmt.Errorf("explanation is not supported by the suggester")
+	}
+
+	return e.Explain(query, value, similarity, metric)
+}
+
+// Rescorer returns a function, that returns the score of a candidate by the re-scoring metric
+func (n *nGramIndex) Rescorer(query string, metric metric.Metric, documents int) (func(value string) (float64, error), error) {
+	r, ok := n.suggester.(rescorer)
+
+	if !ok {
+		return nil, fmt.Errorf("re-scoring is not supported by the suggester")
+	}
+
+	return r.Rescorer(query, metric, documents)
 }
 
-// Autocomplete returns candidates where the query string is a substring of each candidate
-func (n *nGramIndex) Autocomplete(query string, factory CollectorManagerFactory) ([]Candidate, error) {
-	return n.autocomplete.Autocomplete(query, factory)
+// Autocomplete returns candidates where the query string is a prefix of each candidate
+func (n *nGramIndex) Autocomplete(ctx context.Context, query string, factory CollectorManagerFactory) ([]Candidate, error) {
+	if n.autocomplete == nil {
+		return nil, fmt.Errorf("autocomplete is not supported by the index")
+	}
+
+	return n.autocomplete.Autocomplete(ctx, query, factory)
+}
+
+// FuzzyAutocomplete returns candidates, which prefix differs from the query string by at most maxErrors edits
+func (n *nGramIndex) FuzzyAutocomplete(
+	ctx context.Context,
+	query string,
+	maxErrors int,
+	factory CollectorManagerFactory,
+) ([]Candidate, error) {
+	fuzzy, ok := n.autocomplete.(fuzzyAutocomplete)
+
+	if !ok {
+		return nil, fmt.Errorf("typo-tolerant autocomplete is not supported by the index")
+	}
+
+	return fuzzy.FuzzyAutocomplete(ctx, query, maxErrors, factory)
+}
+
+// InfixAutocomplete returns candidates where the query string matches each candidate with the given matching
+func (n *nGramIndex) InfixAutocomplete(
+	ctx context.Context,
+	query string,
+	matching AutocompleteMatching,
+	factory CollectorManagerFactory,
+) ([]Candidate, error) {
+	autocomplete, ok := n.infix[matching]
+
+	if !ok {
+		return nil, fmt.Errorf("autocomplete matching %s is not supported by the index", matching)
+	}
+
+	return autocomplete.Autocomplete(ctx, query, factory)
+}
+
+// Close releases the underlying inverted index indices.
+// The index must not be used after this call
+func (n *nGramIndex) Close() error {
+	if closer, ok := n.indices.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
 }
@@ -3,6 +3,7 @@ package suggest
 import (
 	"fmt"
 
+	"github.com/suggest-go/suggest/pkg/analysis"
 	"github.com/suggest-go/suggest/pkg/dictionary"
 	"github.com/suggest-go/suggest/pkg/merger"
 	"github.com/suggest-go/suggest/pkg/store"
@@ -16,18 +17,34 @@ type Builder interface {
 	Build() (NGramIndex, error)
 }
 
+// indexReader reads inverted index indices from a storage
+type indexReader interface {
+	// Read reads inverted index indices
+	Read() (index.InvertedIndexIndices, error)
+}
+
 // builderImpl implements Builder interface
 type builderImpl struct {
-	indexReader *index.Reader
+	indexReader indexReader
 	description IndexDescription
+	tokenizer   analysis.Tokenizer
 }
 
 // NewRAMBuilder creates a search index by using the given dictionary and the index description
 // in a RAMDriver directory
 func NewRAMBuilder(dict dictionary.Dictionary, description IndexDescription) (Builder, error) {
+	if err := description.validate(); err != nil {
+		return nil, fmt.Errorf("invalid index description: %w", err)
+	}
+
 	directory := store.NewRAMDirectory()
+	tokenizer, err := BuildIndexTokenizer(directory, dict, description)
 
-	if err := Index(directory, dict, description.GetWriterConfig(), description.GetIndexTokenizer()); err != nil {
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a ram search index: %w", err)
+	}
+
+	if err := Index(directory, dict, description.GetWriterConfig(), tokenizer); err != nil {
 		return nil, fmt.Errorf("failed to create a ram search index: %w", err)
 	}
 
@@ -47,12 +64,23 @@ func NewFSBuilder(description IndexDescription) (Builder, error) {
 
 // NewBuilder works with already indexed data
 func NewBuilder(directory store.Directory, description IndexDescription) (Builder, error) {
+	if err := description.validate(); err != nil {
+		return nil, fmt.Errorf("invalid index description: %w", err)
+	}
+
+	tokenizer, err := OpenIndexTokenizer(directory, description)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return &builderImpl{
-		indexReader: index.NewIndexReader(
+		indexReader: index.NewSegmentedIndexReader(
 			directory,
-			description.GetWriterConfig(),
+			description.Name,
 		),
 		description: description,
+		tokenizer:   tokenizer,
 	}, nil
 }
 
@@ -67,17 +95,49 @@ func (b *builderImpl) Build() (NGramIndex, error) {
 	suggester := NewSuggester(
 		invertedIndices,
 		index.NewSearcher(merger.CPMerge()),
-		NewSuggestTokenizer(b.description),
+		b.tokenizer,
 	)
 
+	// the grams of a prefix differ from the grams of the whole string, so only the n-grams can be autocompleted
+	if b.description.Tokenizer == VGramTokenizer {
+		return &nGramIndex{
+			suggester: suggester,
+			indices:   invertedIndices,
+		}, nil
+	}
+
+	tokenizer, err := NewAutocompleteTokenizer(b.description)
+
+	if err != nil {
+		return nil, err
+	}
+
 	autocomplete := NewAutocomplete(
 		invertedIndices,
 		index.NewSearcher(merger.CPMerge()),
-		NewAutocompleteTokenizer(b.description),
+		tokenizer,
 	)
 
-	return NewNGramIndex(
-		suggester,
-		autocomplete,
-	), nil
+	infix := make(map[AutocompleteMatching]Autocomplete, 2)
+
+	for _, matching := range []AutocompleteMatching{WordBoundaryMatching, InfixMatching} {
+		tokenizer, err := NewInfixAutocompleteTokenizer(b.description, matching)
+
+		if err != nil {
+			return nil, err
+		}
+
+		infix[matching] = NewAutocomplete(
+			invertedIndices,
+			index.NewSearcher(merger.CPMerge()),
+			tokenizer,
+		)
+	}
+
+	return &nGramIndex{
+		suggester:    suggester,
+		autocomplete: autocomplete,
+		infix:        infix,
+		indices:      invertedIndices,
+	}, nil
 }
@@ -2,6 +2,7 @@ package suggest
 
 import (
 	"bufio"
+	"context"
 	"log"
 	"os"
 	"testing"
@@ -26,7 +27,7 @@ func TestSuggestAuto(t *testing.T) {
 
 	nGramIndex := buildNGramIndex(collection)
 
-	candidates, err := nGramIndex.Suggest("Nissan ma", 0.5, metric.JaccardMetric(), newFuzzyCollectorManager(2))
+	candidates, err := nGramIndex.Suggest(context.Background(), "Nissan ma", 0.5, metric.JaccardMetric(), newFuzzyCollectorManager(2))
 	assert.NoError(t, err)
 
 	actual := make([]index.Position, 0, len(candidates))
@@ -53,7 +54,7 @@ func TestAutoComplete(t *testing.T) {
 
 	nGramIndex := buildNGramIndex(collection)
 
-	candidates, err := nGramIndex.Autocomplete("Niss", newFirstKCollectorManager(5))
+	candidates, err := nGramIndex.Autocomplete(context.Background(), "Niss", newFirstKCollectorManager(5))
 	assert.NoError(t, err)
 
 	actual := make([]index.Position, 0, len(candidates))
@@ -83,7 +84,7 @@ func BenchmarkSuggest(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		nGramIndex.Suggest("Nissan mar", 0.5, metric.CosineMetric(), newFuzzyCollectorManager(5))
+		nGramIndex.Suggest(context.Background(), "Nissan mar", 0.5, metric.CosineMetric(), newFuzzyCollectorManager(5))
 	}
 }
 
@@ -105,27 +106,19 @@ func BenchmarkAutoComplete(b *testing.B) {
 	b.ResetTimer()
 
 	for i := 0; i < b.N; i++ {
-		nGramIndex.Autocomplete(collection[i%qLen], newFirstKCollectorManager(5))
+		nGramIndex.Autocomplete(context.Background(), collection[i%qLen], newFirstKCollectorManager(5))
 	}
 }
 
 func BenchmarkRealExampleInMemory(b *testing.B) {
-	file, err := os.Open("testdata/cars.dict")
-
-	if err != nil {
-		b.Errorf("Unexpected error: %v", err)
-	}
-
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	collection := make([]string, 0)
+	nGramIndex := buildNGramIndex(readCarsCollection(b))
 
-	for scanner.Scan() {
-		collection = append(collection, scanner.Text())
-	}
+	b.ResetTimer()
+	benchmarkRealExample(b, nGramIndex)
+}
 
-	nGramIndex := buildNGramIndex(collection)
+func BenchmarkRealExampleVGramInMemory(b *testing.B) {
+	nGramIndex := buildTokenizedIndex(readCarsCollection(b), VGramTokenizer)
 
 	b.ResetTimer()
 	benchmarkRealExample(b, nGramIndex)
@@ -160,7 +153,7 @@ func BenchmarkSuggestWordsOnDisc(b *testing.B) {
 	qLen := len(queries)
 
 	for i := 0; i < b.N; i++ {
-		index.Suggest(queries[i%qLen], 0.5, metric.CosineMetric(), newFuzzyCollectorManager(5))
+		index.Suggest(context.Background(), queries[i%qLen], 0.5, metric.CosineMetric(), newFuzzyCollectorManager(5))
 	}
 }
 
@@ -186,7 +179,7 @@ func BenchmarkAutocompleteWordsOnDisc(b *testing.B) {
 	qLen := len(queries)
 
 	for i := 0; i < b.N; i++ {
-		index.Autocomplete(queries[i%qLen], newFirstKCollectorManager(5))
+		index.Autocomplete(context.Background(), queries[i%qLen], newFirstKCollectorManager(5))
 	}
 }
 
@@ -208,13 +201,37 @@ func benchmarkRealExample(b *testing.B, index NGramIndex) {
 	qLen := len(queries)
 
 	for i := 0; i < b.N; i++ {
-		index.Suggest(queries[i%qLen], 0.5, metric.CosineMetric(), newFuzzyCollectorManager(5))
+		index.Suggest(context.Background(), queries[i%qLen], 0.5, metric.CosineMetric(), newFuzzyCollectorManager(5))
+	}
+}
+
+func readCarsCollection(b *testing.B) []string {
+	file, err := os.Open("testdata/cars.dict")
+
+	if err != nil {
+		b.Errorf("Unexpected error: %v", err)
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	collection := make([]string, 0)
+
+	for scanner.Scan() {
+		collection = append(collection, scanner.Text())
 	}
+
+	return collection
 }
 
 func buildNGramIndex(collection []string) NGramIndex {
+	return buildTokenizedIndex(collection, NGramTokenizer)
+}
+
+func buildTokenizedIndex(collection []string, tokenizer TokenizerType) NGramIndex {
 	config := IndexDescription{
 		Driver:    RAMDriver,
+		Tokenizer: tokenizer,
 		Name:      "index",
 		NGramSize: 3,
 		Pad:       "$",
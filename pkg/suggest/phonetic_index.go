@@ -0,0 +1,406 @@
+package suggest
+
+import (
+	"encoding/gob"
+	"fmt"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+// phoneticCandidatesFactor tells how many times more documents than requested, that sound like the query
+// but are absent among the n-gram candidates, are blended, so the weight formula has a choice among them
+const phoneticCandidatesFactor = 4
+
+// WithPhoneticBoost makes Suggest blend the n-gram score of a candidate with the share of the query words,
+// that sound like a word of the candidate: score = (1 - boost) * score + boost * phonetic score.
+// The documents, that sound like the query but are not similar enough by n-grams, are returned as well,
+// i.e. "fotograf" finds "photograph". The boost should be in (0, 1], the index should be described with Phonetic encoders
+func WithPhoneticBoost(boost float64) QueryOption {
+	return func(options *queryOptions) {
+		options.phoneticBoost = boost
+	}
+}
+
+// phoneticIndex keeps the phonetic keys of the document words as extra terms,
+// each key refers to the list of documents having a word with this key
+type phoneticIndex struct {
+	splitter analysis.Tokenizer
+	// names are the names of the encoders, that the keys of the on-disc index are persisted with
+	names    []string
+	encoders []analysis.PhoneticEncoder
+	// documents holds the posting lists of the keys of each encoder
+	documents []map[string]*roaring.Bitmap
+}
+
+// newEmptyPhoneticIndex creates a phonetic index without documents for the encoders of the description
+func newEmptyPhoneticIndex(description IndexDescription) (*phoneticIndex, error) {
+	encoders, err := description.phoneticEncoders()
+
+	if err != nil {
+		return nil, err
+	}
+
+	splitter, err := newWordSplitter(description)
+
+	if err != nil {
+		return nil, err
+	}
+
+	index := &phoneticIndex{
+		splitter:  splitter,
+		names:     description.Phonetic,
+		encoders:  encoders,
+		documents: make([]map[string]*roaring.Bitmap, len(encoders)),
+	}
+
+	for i := range index.documents {
+		index.documents[i] = map[string]*roaring.Bitmap{}
+	}
+
+	return index, nil
+}
+
+// newPhoneticIndex splits the documents into words and indexes
+// their keys produced by the phonetic encoders of the description
+func newPhoneticIndex(docs dictionary.Iterable, description IndexDescription) (*phoneticIndex, error) {
+	index, err := newEmptyPhoneticIndex(description)
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = docs.Iterate(func(key dictionary.Key, value dictionary.Value) error {
+		index.add(key, value)
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode document words: %w", err)
+	}
+
+	for _, documents := range index.documents {
+		for _, list := range documents {
+			list.RunOptimize()
+		}
+	}
+
+	return index, nil
+}
+
+// openPhoneticIndex reads the phonetic keys persisted along with the segments of the on-disc index
+// with the given description. The keys are encoded from the dictionary, if a segment has been written
+// without them or with other encoders, i.e. before the encoders have been described
+func openPhoneticIndex(directory store.Directory, description IndexDescription, dict dictionary.Dictionary) (*phoneticIndex, error) {
+	infos, err := index.ReadSegmentInfos(directory, description.Name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read segments: %w", err)
+	}
+
+	keys := Attributes{}
+
+	for _, info := range infos.Segments {
+		deletions, err := index.ReadDeletions(directory, info)
+
+		if err != nil {
+			return nil, err
+		}
+
+		segment, err := readPhoneticKeys(directory, phoneticFileName(info.Name))
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read phonetic keys of segment %s: %w", info.Name, err)
+		}
+
+		if segment == nil || !sameNames(segment.Encoders, description.Phonetic) {
+			return newPhoneticIndex(dict, description)
+		}
+
+		keys.merge(segment.Keys, deletions)
+	}
+
+	phonetic, err := newEmptyPhoneticIndex(description)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i, name := range phonetic.names {
+		if documents, ok := keys[name]; ok {
+			phonetic.documents[i] = documents
+		}
+	}
+
+	return phonetic, nil
+}
+
+// add adds the keys of the words of the given document value
+func (p *phoneticIndex) add(key dictionary.Key, value dictionary.Value) {
+	for _, word := range p.splitter.Tokenize(value) {
+		for i, encoder := range p.encoders {
+			for _, code := range encoder.Encode(word) {
+				p.list(i, code).Add(key)
+			}
+		}
+	}
+}
+
+// keys returns the posting lists of the keys of each encoder by the encoder name
+func (p *phoneticIndex) keys() Attributes {
+	keys := make(Attributes, len(p.names))
+
+	for i, name := range p.names {
+		keys[name] = p.documents[i]
+	}
+
+	return keys
+}
+
+// update returns a new index with the given changes applied, the previous values of the changed documents
+// are looked up in prev. The new index shares the posting lists of the unchanged keys with the index,
+// so the index itself is left untouched and can still be used by the queries in flight
+func (p *phoneticIndex) update(prev dictionary.Dictionary, docs []Document, deletes []dictionary.Key) (*phoneticIndex, error) {
+	index := &phoneticIndex{
+		splitter:  p.splitter,
+		names:     p.names,
+		encoders:  p.encoders,
+		documents: make([]map[string]*roaring.Bitmap, len(p.documents)),
+	}
+
+	for i, documents := range p.documents {
+		index.documents[i] = make(map[string]*roaring.Bitmap, len(documents))
+
+		for code, list := range documents {
+			index.documents[i][code] = list
+		}
+	}
+
+	lists := newCopyOnWriteLists()
+
+	err := forEachChange(prev, docs, deletes, func(key dictionary.Key, old, value dictionary.Value) {
+		for _, word := range index.splitter.Tokenize(old) {
+			for i, encoder := range index.encoders {
+				for _, code := range encoder.Encode(word) {
+					if list, ok := index.documents[i][code]; ok {
+						list = lists.get(list)
+						list.Remove(key)
+						index.documents[i][code] = list
+					}
+				}
+			}
+		}
+
+		for _, word := range index.splitter.Tokenize(value) {
+			for i, encoder := range index.encoders {
+				for _, code := range encoder.Encode(word) {
+					list := lists.get(index.list(i, code))
+					list.Add(key)
+					index.documents[i][code] = list
+				}
+			}
+		}
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode changed document words: %w", err)
+	}
+
+	return index, nil
+}
+
+// list returns the posting list of the given key of the i-th encoder, the missing list is created
+func (p *phoneticIndex) list(i int, code string) *roaring.Bitmap {
+	list, ok := p.documents[i][code]
+
+	if !ok {
+		list = roaring.New()
+		p.documents[i][code] = list
+	}
+
+	return list
+}
+
+// Match returns the phonetic scores of the given candidates and of at most limit other documents, that sound
+// like the query. The score is the share of the query words, that sound like a word of the document, the other
+// documents are taken in the descending order of their scores. The query words, that can't be encoded,
+// are not taken into account. The documents out of the filter are skipped, if it is set
+func (p *phoneticIndex) Match(query string, candidates []Candidate, limit int, filter *roaring.Bitmap) map[dictionary.Key]float64 {
+	matches := []*roaring.Bitmap{}
+
+	for _, word := range p.splitter.Tokenize(query) {
+		matched := roaring.New()
+		encoded := false
+
+		for i, encoder := range p.encoders {
+			for _, code := range encoder.Encode(word) {
+				encoded = true
+
+				if list, ok := p.documents[i][code]; ok {
+					matched.Or(list)
+				}
+			}
+		}
+
+		if !encoded {
+			continue
+		}
+
+		if filter != nil {
+			matched.And(filter)
+		}
+
+		matches = append(matches, matched)
+	}
+
+	scores := map[dictionary.Key]float64{}
+
+	if len(matches) == 0 {
+		return scores
+	}
+
+	words := float64(len(matches))
+	excluded := roaring.New()
+
+	for _, candidate := range candidates {
+		excluded.Add(candidate.Key)
+		count := 0
+
+		for _, matched := range matches {
+			if matched.Contains(candidate.Key) {
+				count++
+			}
+		}
+
+		if count > 0 {
+			scores[candidate.Key] = float64(count) / words
+		}
+	}
+
+	// atLeast[i] holds the documents, that sound like more than i query words,
+	// so the best documents are found without scoring each document sharing a key with the query
+	atLeast := make([]*roaring.Bitmap, len(matches))
+
+	for i := range atLeast {
+		atLeast[i] = roaring.New()
+	}
+
+	for _, matched := range matches {
+		for i := len(atLeast) - 1; i > 0; i-- {
+			atLeast[i].Or(roaring.And(atLeast[i-1], matched))
+		}
+
+		atLeast[0].Or(matched)
+	}
+
+	found := 0
+
+	for i := len(atLeast) - 1; i >= 0 && found < limit; i-- {
+		it := atLeast[i].Iterator()
+
+		for it.HasNext() && found < limit {
+			key := it.Next()
+
+			if _, ok := scores[key]; ok || excluded.Contains(key) {
+				continue
+			}
+
+			scores[key] = float64(i+1) / words
+			found++
+		}
+	}
+
+	return scores
+}
+
+// phoneticKeys are the persisted phonetic keys of the documents of an index segment,
+// Keys holds the posting lists of the keys of each encoder named in Encoders
+type phoneticKeys struct {
+	Encoders []string
+	Keys     Attributes
+}
+
+// readPhoneticKeys reads the phonetic keys file with the given name.
+// Returns nil if the segment has been written without the phonetic keys
+func readPhoneticKeys(directory store.Directory, fileName string) (*phoneticKeys, error) {
+	if !directory.Exists(fileName) {
+		return nil, nil
+	}
+
+	input, err := directory.OpenInput(fileName)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open phonetic keys file: %w", err)
+	}
+
+	keys := &phoneticKeys{}
+
+	if err := gob.NewDecoder(input).Decode(keys); err != nil {
+		return nil, fmt.Errorf("failed to decode phonetic keys: %w", err)
+	}
+
+	if keys.Keys == nil {
+		keys.Keys = Attributes{}
+	}
+
+	return keys, input.Close()
+}
+
+// writePhoneticKeys persists the given phonetic keys of the named encoders into the file with the given name
+func writePhoneticKeys(directory store.Directory, fileName string, encoders []string, keys Attributes) error {
+	output, err := directory.CreateOutput(fileName)
+
+	if err != nil {
+		return fmt.Errorf("failed to create phonetic keys file: %w", err)
+	}
+
+	for _, documents := range keys {
+		for _, list := range documents {
+			list.RunOptimize()
+		}
+	}
+
+	if err := gob.NewEncoder(output).Encode(phoneticKeys{Encoders: encoders, Keys: keys}); err != nil {
+		return fmt.Errorf("failed to encode phonetic keys: %w", err)
+	}
+
+	if err := output.Close(); err != nil {
+		return fmt.Errorf("failed to close phonetic keys file: %w", err)
+	}
+
+	return nil
+}
+
+// writeSegmentPhoneticKeys persists the keys of the given phonetic index of a segment into the file with the given name,
+// nothing is written if the index has no encoders
+func writeSegmentPhoneticKeys(directory store.Directory, fileName string, phonetic *phoneticIndex) error {
+	if len(phonetic.encoders) == 0 {
+		return nil
+	}
+
+	return writePhoneticKeys(directory, fileName, phonetic.names, phonetic.keys())
+}
+
+// phoneticFileName returns the name of the phonetic keys file of the given index segment
+func phoneticFileName(segment string) string {
+	return fmt.Sprintf("%s.phon", segment)
+}
+
+// sameNames tells whether the given lists of names are equal
+func sameNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,198 @@
+package suggest
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+func TestPhoneticMatch(t *testing.T) {
+	dict := dictionary.NewInMemoryDictionary([]string{
+		"Photograph Studio",
+		"Fotografia",
+		"Stewdio Smyth",
+		"Photo Studio",
+		"Smith Photograph Stodio",
+		"Nissan",
+	})
+
+	phonetic, err := newPhoneticIndex(dict, IndexDescription{
+		Alphabet: []string{"english"},
+		Phonetic: []string{"doubleMetaphone"},
+	})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		candidates []Candidate
+		limit      int
+		filter     *roaring.Bitmap
+		expected   map[dictionary.Key]float64
+	}{
+		{
+			name:     "the documents sounding like each query word come first",
+			limit:    2,
+			expected: map[dictionary.Key]float64{0: 1, 4: 1},
+		},
+		{
+			name:     "the limit is filled with the documents sounding like fewer words",
+			limit:    3,
+			expected: map[dictionary.Key]float64{0: 1, 4: 1, 1: 0.5},
+		},
+		{
+			name:       "the candidates are scored beyond the limit",
+			candidates: []Candidate{{Key: 5}, {Key: 3}},
+			limit:      1,
+			expected:   map[dictionary.Key]float64{3: 0.5, 0: 1},
+		},
+		{
+			name:     "the documents out of the filter are skipped",
+			limit:    10,
+			filter:   roaring.BitmapOf(1, 2, 3, 5),
+			expected: map[dictionary.Key]float64{1: 0.5, 2: 0.5, 3: 0.5},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := phonetic.Match("fotograf stodio", testCase.candidates, testCase.limit, testCase.filter)
+			assert.Equal(t, testCase.expected, actual)
+		})
+	}
+
+	assert.Empty(t, phonetic.Match("12345", nil, 10, nil))
+}
+
+func TestPersistedPhoneticKeys(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	outputPath, err := ioutil.TempDir("", "suggest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(outputPath)
+
+	description := descriptions[0]
+	description.OutputPath = outputPath
+	description.Phonetic = []string{"doubleMetaphone"}
+
+	directory, err := store.NewFSDirectory(outputPath)
+	assert.NoError(t, err)
+
+	dict, err := BuildDictionary(directory, description)
+	assert.NoError(t, err)
+
+	tokenizer, err := description.GetIndexTokenizer()
+	assert.NoError(t, err)
+	assert.NoError(t, Index(directory, dict, description.GetWriterConfig(), tokenizer))
+
+	// each segment has its keys, the keys read from the segments should be the same as the ones encoded from scratch
+	assertOpenedPhoneticKeys := func() {
+		infos, err := index.ReadSegmentInfos(directory, description.Name)
+		assert.NoError(t, err)
+
+		for _, info := range infos.Segments {
+			assert.True(t, directory.Exists(phoneticFileName(info.Name)), info.Name)
+		}
+
+		dict, err := OpenSegmentedDictionary(directory, description)
+		assert.NoError(t, err)
+		defer closeDictionary(dict)
+
+		expected, err := newPhoneticIndex(dict, description)
+		assert.NoError(t, err)
+
+		actual, err := openPhoneticIndex(directory, description, dict)
+		assert.NoError(t, err)
+
+		assert.Equal(t, liveKeys(expected), liveKeys(actual))
+	}
+
+	assertOpenedPhoneticKeys()
+
+	assert.NoError(t, UpdateIndex(directory, description, []Document{
+		{Key: 0, Value: "LADA VESTA"},
+		{Key: 100000, Value: "FOTOGRAF STUDIO"},
+	}, []dictionary.Key{1, 2}))
+	assertOpenedPhoneticKeys()
+
+	assert.NoError(t, Compact(directory, description))
+	assertOpenedPhoneticKeys()
+
+	// the persisted keys are read as is, the dictionary isn't encoded again
+	infos, err := index.ReadSegmentInfos(directory, description.Name)
+	assert.NoError(t, err)
+	assert.Len(t, infos.Segments, 1)
+
+	assert.NoError(t, writePhoneticKeys(directory, phoneticFileName(infos.Segments[0].Name), description.Phonetic, Attributes{
+		description.Phonetic[0]: {"XXX": roaring.BitmapOf(7)},
+	}))
+
+	phonetic, err := openPhoneticIndex(directory, description, dictionary.NewInMemoryDictionary(nil))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]uint32{"XXX": {7}}, liveKeys(phonetic)[0])
+
+	// the keys of other encoders are encoded from the dictionary
+	description.Phonetic = []string{"soundex"}
+	phonetic, err = openPhoneticIndex(directory, description, dictionary.NewInMemoryDictionary([]string{"Lada"}))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]uint32{"L300": {0}}, liveKeys(phonetic)[0])
+}
+
+// liveKeys returns the documents of the non-empty posting lists of the keys of each encoder
+func liveKeys(phonetic *phoneticIndex) []map[string][]uint32 {
+	keys := make([]map[string][]uint32, 0, len(phonetic.documents))
+
+	for _, documents := range phonetic.documents {
+		lists := map[string][]uint32{}
+
+		for code, list := range documents {
+			if !list.IsEmpty() {
+				lists[code] = list.ToArray()
+			}
+		}
+
+		keys = append(keys, lists)
+	}
+
+	return keys
+}
+
+func TestPhoneticBoost(t *testing.T) {
+	service, description := newTestService(t, "Photograph studio\nPhotography\nFotomagazin\nСергеев Иван\n", nil)
+
+	searchConf, err := NewSearchConfig("fotograf", 5, metric.CosineMetric(), 0.5, WithPhoneticBoost(0.5))
+	assert.NoError(t, err)
+
+	_, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.Error(t, err)
+
+	description.Phonetic = []string{"doubleMetaphone", "russianMetaphone"}
+	assert.NoError(t, service.AddRunTimeIndex(description))
+
+	// "fotograf" is not similar enough to "photograph" by n-grams, but sounds like it
+	result, err := service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Photograph studio", "Photography"}, resultValues(result))
+	assert.Equal(t, 0.5, result[0].Score)
+
+	searchConf, err = NewSearchConfig("сиргеев", 5, metric.CosineMetric(), 0.5, WithPhoneticBoost(0.5))
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Сергеев Иван"}, resultValues(result))
+
+	_, err = NewSearchConfig("fotograf", 5, metric.CosineMetric(), 0.5, WithPhoneticBoost(2))
+	assert.Error(t, err)
+
+	description.Phonetic = []string{"caverphone"}
+	assert.Error(t, service.AddRunTimeIndex(description))
+}
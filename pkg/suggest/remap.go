@@ -0,0 +1,120 @@
+package suggest
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+)
+
+// keyboardRemapping searches a query also as if it was typed with other keyboard layouts
+type keyboardRemapping struct {
+	layouts []analysis.KeyboardLayout
+	penalty float64
+}
+
+// WithKeyboardLayouts makes a query also look for the query remapped through each of the given
+// keyboard layouts, i.e. "иьц" is also searched as "bmw". The scores of the candidates found only by
+// a remapped query are multiplied by the penalty, which should be in (0, 1]
+func WithKeyboardLayouts(penalty float64, layouts ...analysis.KeyboardLayout) QueryOption {
+	return func(options *queryOptions) {
+		options.remapping = &keyboardRemapping{
+			layouts: layouts,
+			penalty: penalty,
+		}
+	}
+}
+
+// validate checks the parameters of the remapping
+func (r *keyboardRemapping) validate() error {
+	if r.penalty <= 0 || r.penalty > 1 {
+		return fmt.Errorf("keyboard layout penalty should be in (0.0, 1.0], got %v", r.penalty)
+	}
+
+	return nil
+}
+
+// search calls the search function for the query and for each of its remappings and merges
+// the found candidates into the list of at most limit ones. If scored is false, the candidates
+// of the remapped queries just follow the candidates of the original one
+func (r *keyboardRemapping) search(
+	query string,
+	limit int,
+	scored bool,
+	search func(query string) ([]Candidate, error),
+) ([]Candidate, error) {
+	candidates, err := search(query)
+
+	if err != nil {
+		return candidates, err
+	}
+
+	for _, remapped := range r.variants(query) {
+		found, err := search(remapped)
+		candidates = r.merge(candidates, found, scored)
+
+		if err != nil {
+			break
+		}
+	}
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	return candidates, err
+}
+
+// variants returns the distinct remappings of the query, that differ from it
+func (r *keyboardRemapping) variants(query string) []string {
+	variants := make([]string, 0, len(r.layouts))
+
+	for _, layout := range r.layouts {
+		remapped := layout.Remap(query)
+		duplicate := remapped == query
+
+		for _, variant := range variants {
+			duplicate = duplicate || variant == remapped
+		}
+
+		if !duplicate {
+			variants = append(variants, remapped)
+		}
+	}
+
+	return variants
+}
+
+// merge appends the penalised candidates of a remapped query to the given ones.
+// A candidate found by both queries keeps the best of its scores
+func (r *keyboardRemapping) merge(candidates, remapped []Candidate, scored bool) []Candidate {
+	positions := make(map[dictionary.Key]int, len(candidates))
+
+	for i, candidate := range candidates {
+		positions[candidate.Key] = i
+	}
+
+	for _, candidate := range remapped {
+		if scored {
+			candidate.Score *= r.penalty
+		}
+
+		i, ok := positions[candidate.Key]
+
+		if !ok {
+			positions[candidate.Key] = len(candidates)
+			candidates = append(candidates, candidate)
+		} else if scored && candidates[i].Score < candidate.Score {
+			candidates[i].Score = candidate.Score
+		}
+	}
+
+	if scored {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Score > candidates[j].Score
+		})
+	}
+
+	return candidates
+}
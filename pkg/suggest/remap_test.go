@@ -0,0 +1,69 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestKeyboardRemapping(t *testing.T) {
+	remapping := &keyboardRemapping{
+		layouts: []analysis.KeyboardLayout{
+			analysis.JcukenToQwertyLayout,
+			analysis.QwertyToJcukenLayout,
+			analysis.JcukenToQwertyLayout,
+		},
+		penalty: 0.5,
+	}
+
+	// the unchanged and duplicate remappings are skipped
+	assert.Equal(t, []string{"bmw"}, remapping.variants("иьц"))
+	assert.Equal(t, []string{"маз"}, remapping.variants("vfp"))
+
+	candidates := []Candidate{{Key: 1, Score: 0.9}, {Key: 2, Score: 0.3}}
+	remapped := []Candidate{{Key: 3, Score: 1}, {Key: 2, Score: 0.8}}
+
+	// a candidate keeps the best of its scores, the remapped ones are penalised
+	merged := remapping.merge(append([]Candidate{}, candidates...), remapped, true)
+	assert.Equal(t, []Candidate{{Key: 1, Score: 0.9}, {Key: 3, Score: 0.5}, {Key: 2, Score: 0.4}}, merged)
+
+	// the unscored remapped candidates just follow the original ones
+	merged = remapping.merge(append([]Candidate{}, candidates...), remapped, false)
+	assert.Equal(t, []Candidate{{Key: 1, Score: 0.9}, {Key: 2, Score: 0.3}, {Key: 3, Score: 1}}, merged)
+
+	assert.Error(t, (&keyboardRemapping{penalty: 0}).validate())
+	assert.Error(t, (&keyboardRemapping{penalty: 1.5}).validate())
+	assert.NoError(t, remapping.validate())
+}
+
+func TestKeyboardLayouts(t *testing.T) {
+	service, description := newTestService(t, "BMW X5\nМазда 6\nBMW X6\n", nil)
+
+	layouts := WithKeyboardLayouts(0.5, analysis.JcukenToQwertyLayout, analysis.QwertyToJcukenLayout)
+
+	result, err := service.Autocomplete(context.Background(), description.Name, "иьц", 5)
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	result, err = service.Autocomplete(context.Background(), description.Name, "иьц", 5, layouts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BMW X5", "BMW X6"}, resultValues(result))
+
+	result, err = service.Autocomplete(context.Background(), description.Name, "vfp", 5, layouts)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Мазда 6"}, resultValues(result))
+
+	searchConf, err := NewSearchConfig("иьц ч5", 5, metric.CosineMetric(), 0.5, layouts)
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, "BMW X5", result[0].Value)
+	assert.Equal(t, 0.5, result[0].Score)
+
+	_, err = NewSearchConfig("иьц", 5, metric.CosineMetric(), 0.5, WithKeyboardLayouts(0, analysis.JcukenToQwertyLayout))
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+package suggest
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+// EditDistanceReranking is the second stage of Suggest, that re-scores
+// the best found candidates by their edit distance to the query
+type EditDistanceReranking struct {
+	// Distance is an edit distance between the query and a candidate
+	Distance metric.EditDistance
+	// TopN is the number of the best candidates to re-score, topK of the query is used if it is bigger
+	TopN int
+	// MaxDistance drops the candidates, which distance to the query is bigger, 0 means no limit
+	MaxDistance float64
+}
+
+// WithEditDistanceReranking makes Suggest re-score its best candidates with the given edit distance
+func WithEditDistanceReranking(reranking EditDistanceReranking) QueryOption {
+	return func(options *queryOptions) {
+		options.reranking = &reranking
+	}
+}
+
+// limit returns the number of candidates, that should be found for the reranking of topK items
+func (r *EditDistanceReranking) limit(topK int) int {
+	if r.TopN > topK {
+		return r.TopN
+	}
+
+	return topK
+}
+
+// rerank scores the given items as 1 - distance / maxDistance, so the closest items are ranked first,
+// and returns topK of them. The items with the same distance keep their original order. If the query
+// has keyboard remappings, an item is also scored against them with the remapping penalty
+func (r *EditDistanceReranking) rerank(query string, remapping *keyboardRemapping, items []ResultItem, topK int) []ResultItem {
+	queries := []string{query}
+	penalties := []float64{1}
+
+	if remapping != nil {
+		for _, variant := range remapping.variants(query) {
+			queries = append(queries, variant)
+			penalties = append(penalties, remapping.penalty)
+		}
+	}
+
+	reranked := make([]ResultItem, 0, len(items))
+
+	for _, item := range items {
+		found := false
+		item.Score = 0
+
+		for i, query := range queries {
+			score, ok := r.score(query, item.Value)
+
+			if ok && score*penalties[i] >= item.Score {
+				found = true
+				item.Score = score * penalties[i]
+			}
+		}
+
+		if found {
+			if item.Explanation != nil {
+				item.Explanation.Reranked = true
+			}
+
+			reranked = append(reranked, item)
+		}
+	}
+
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return reranked[i].Score > reranked[j].Score
+	})
+
+	if len(reranked) > topK {
+		reranked = reranked[:topK]
+	}
+
+	return reranked
+}
+
+// score returns the similarity of the query and the value, that is based on their edit distance.
+// Returns false if the value is too far from the query
+func (r *EditDistanceReranking) score(query, value string) (float64, bool) {
+	query, value = strings.ToLower(query), strings.ToLower(value)
+	bound := math.Inf(1)
+
+	if r.MaxDistance > 0 {
+		bound = r.MaxDistance
+	}
+
+	distance := r.Distance.Distance(query, value, bound)
+
+	if distance > bound {
+		return 0, false
+	}
+
+	if maxDistance := r.Distance.MaxDistance(query, value); maxDistance > 0 {
+		return 1 - distance/maxDistance, true
+	}
+
+	return 1, true
+}
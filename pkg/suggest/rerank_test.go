@@ -0,0 +1,71 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestRerank(t *testing.T) {
+	reranking := &EditDistanceReranking{
+		Distance: metric.LevenshteinDistance(metric.DefaultEditCosts),
+		TopN:     10,
+	}
+
+	items := []ResultItem{
+		{Score: 0.9, Value: "Mercedez"},
+		{Score: 0.8, Value: "Mercedes"},
+		{Score: 0.7, Value: "Mersedes"},
+		{Score: 0.6, Value: "Mersedes Benz"},
+	}
+
+	assert.Equal(t, 10, reranking.limit(3))
+	assert.Equal(t, 20, reranking.limit(20))
+
+	// the closest items come first regardless of their previous scores and the letter case
+	actual := reranking.rerank("MERSEDES", nil, items, 3)
+	assert.Equal(t, []string{"Mersedes", "Mercedes", "Mercedez"}, resultValues(actual))
+	assert.Equal(t, 1.0, actual[0].Score)
+	assert.Equal(t, 1-1.0/16, actual[1].Score)
+
+	reranking.MaxDistance = 1
+	assert.Equal(t, []string{"Mersedes", "Mercedes"}, resultValues(reranking.rerank("mersedes", nil, items, 3)))
+
+	// the remapped query is scored with the penalty
+	remapping := &keyboardRemapping{layouts: []analysis.KeyboardLayout{analysis.JcukenToQwertyLayout}, penalty: 0.5}
+	actual = reranking.rerank("ьукыувуы", remapping, items, 3)
+	assert.Equal(t, []string{"Mersedes", "Mercedes"}, resultValues(actual))
+	assert.Equal(t, 0.5, actual[0].Score)
+}
+
+func TestEditDistanceReranking(t *testing.T) {
+	service, description := newTestService(t, "Mercedes Benz\nMercedez\nMercedes\nMersedes\n", nil)
+
+	reranking := EditDistanceReranking{
+		Distance: metric.LevenshteinDistance(metric.DefaultEditCosts),
+		TopN:     10,
+	}
+
+	searchConf, err := NewSearchConfig("mersedes", 3, metric.CosineMetric(), 0.1, WithEditDistanceReranking(reranking))
+	assert.NoError(t, err)
+
+	result, err := service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mersedes", "Mercedes", "Mercedez"}, resultValues(result))
+	assert.Equal(t, 1.0, result[0].Score)
+	assert.Equal(t, 1-1.0/16, result[1].Score)
+
+	reranking.MaxDistance = 1
+	searchConf, err = NewSearchConfig("mersedes", 3, metric.CosineMetric(), 0.1, WithEditDistanceReranking(reranking))
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mersedes", "Mercedes"}, resultValues(result))
+
+	_, err = NewSearchConfig("mersedes", 3, metric.CosineMetric(), 0.1, WithEditDistanceReranking(EditDistanceReranking{}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,237 @@
+package suggest
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+// rescoreCandidatesFactor tells how many times more candidates than requested are found for
+// a re-scoring metric, because the n-gram search ranks them with the unweighted metric
+const rescoreCandidatesFactor = 4
+
+// isRescoring tells whether the candidates found with the metric should be re-scored,
+// see metric.WeightedMetric and metric.StringMetric
+func isRescoring(m metric.Metric) bool {
+	switch m.(type) {
+	case metric.WeightedMetric, metric.StringMetric:
+		return true
+	default:
+		return false
+	}
+}
+
+// rescorer is a Suggester, that can re-score the candidates with a re-scoring metric
+type rescorer interface {
+	// Rescorer returns a function, that returns the score of the candidate with the given value for the query.
+	// The query is analyzed once, so the function should be reused for the candidates of the same search.
+	// The documents is the number of the indexed documents
+	Rescorer(query string, metric metric.Metric, documents int) (func(value string) (float64, error), error)
+}
+
+// Rescorer returns a function, that returns the score of the candidate with the given value. A metric.StringMetric
+// compares the lowercased strings, a metric.WeightedMetric compares their n-gram sets weighted by IDF, where the
+// document frequency of an n-gram is the total size of its posting lists. The query weights are computed once,
+// and the weights of the candidate n-grams are cached for the rest candidates
+func (n *nGramSuggester) Rescorer(query string, m metric.Metric, documents int) (func(value string) (float64, error), error) {
+	switch m := m.(type) {
+	case metric.StringMetric:
+		query = strings.ToLower(query)
+
+		return func(value string) (float64, error) {
+			return m.Similarity(query, strings.ToLower(value)), nil
+		}, nil
+	case metric.WeightedMetric:
+		queryTerms := n.tokenizer.Tokenize(query)
+		weights := map[index.Term]float64{}
+		a, err := n.termWeights(queryTerms, documents, weights)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return func(value string) (float64, error) {
+			valueTerms := n.tokenizer.Tokenize(value)
+			b, err := n.termWeights(valueTerms, documents, weights)
+
+			if err != nil {
+				return 0, err
+			}
+
+			unmatched := make(map[index.Term]struct{}, len(queryTerms))
+
+			for _, term := range queryTerms {
+				unmatched[term] = struct{}{}
+			}
+
+			shared := []float64{}
+
+			for _, term := range valueTerms {
+				if _, ok := unmatched[term]; ok {
+					shared = append(shared, weights[term])
+					delete(unmatched, term)
+				}
+			}
+
+			return 1 - m.WeightedDistance(shared, a, b), nil
+		}, nil
+	default:
+		return nil, fmt.Errorf("metric %v doesn't re-score candidates", m)
+	}
+}
+
+// termWeights returns the IDF weights of the distinct given terms, the computed weights are cached in the given map
+func (n *nGramSuggester) termWeights(terms []index.Term, documents int, cache map[index.Term]float64) ([]float64, error) {
+	weights := make([]float64, 0, len(terms))
+	seen := make(map[index.Term]struct{}, len(terms))
+
+	for _, term := range terms {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+
+		seen[term] = struct{}{}
+		weight, ok := cache[term]
+
+		if !ok {
+			df, err := n.documentFrequency(term)
+
+			if err != nil {
+				return nil, err
+			}
+
+			weight = metric.IDF(df, documents)
+			cache[term] = weight
+		}
+
+		weights = append(weights, weight)
+	}
+
+	return weights, nil
+}
+
+// documentFrequency returns the number of documents, that contain the term. The posting lists aren't
+// decoded, so the documents deleted from the segments of an on-disc index are counted until a compaction
+func (n *nGramSuggester) documentFrequency(term index.Term) (int, error) {
+	df := 0
+
+	for size := 0; size < n.indices.Size(); size++ {
+		invertedIndex := n.indices.Get(size)
+
+		if invertedIndex == nil {
+			continue
+		}
+
+		count, err := index.DocumentFrequency(invertedIndex, term)
+
+		if err != nil {
+			return 0, fmt.Errorf("failed to count the documents of a term: %w", err)
+		}
+
+		df += count
+	}
+
+	return df, nil
+}
+
+// rescore re-scores the given candidates with the re-scoring metric of the query, drops the ones scored
+// less than the similarity of the query, blends the new scores with the document weights if the query
+// has a weight formula and returns topK best of them
+func (e *indexEntry) rescore(query string, candidates []Candidate, config SearchConfig, topK int) ([]Candidate, error) {
+	score, err := newKeyRescorer(e.index, e.dictionary, query, config.metric)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var blend func(key dictionary.Key, score float64) float64
+
+	if formula := config.options.weightFormula; formula != nil {
+		blend = func(key dictionary.Key, score float64) float64 {
+			return formula(score, e.weights.Get(key), e.weights.Max())
+		}
+	}
+
+	return rescoreCandidates(candidates, score, config.similarity, blend, topK)
+}
+
+// rescorer returns a function, that returns the score of the document with the given key for the query
+// by the re-scoring metric
+func (e *indexEntry) rescorer(query string, m metric.Metric) (func(key dictionary.Key) (float64, error), error) {
+	return newKeyRescorer(e.index, e.dictionary, query, m)
+}
+
+// newKeyRescorer returns a function, that returns the score of the document of the dictionary
+// with the given key for the query by the re-scoring metric
+func newKeyRescorer(
+	suggester NGramIndex,
+	dict dictionary.Dictionary,
+	query string,
+	m metric.Metric,
+) (func(key dictionary.Key) (float64, error), error) {
+	r, ok := suggester.(rescorer)
+
+	if !ok {
+		return nil, fmt.Errorf("metric %v is not supported by the dictionary", m)
+	}
+
+	score, err := r.Rescorer(query, m, dict.Size())
+
+	if err != nil {
+		return nil, err
+	}
+
+	return func(key dictionary.Key) (float64, error) {
+		value, err := dict.Get(key)
+
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch the value of %d: %w", key, err)
+		}
+
+		return score(value)
+	}, nil
+}
+
+// rescoreCandidates re-scores the given candidates with score, drops the ones scored less than the similarity,
+// blends the rest scores with blend if it is set and returns topK best of the candidates
+func rescoreCandidates(
+	candidates []Candidate,
+	score func(key dictionary.Key) (float64, error),
+	similarity float64,
+	blend func(key dictionary.Key, score float64) float64,
+	topK int,
+) ([]Candidate, error) {
+	rescored := make([]Candidate, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		value, err := score(candidate.Key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if value < similarity {
+			continue
+		}
+
+		if blend != nil {
+			value = blend(candidate.Key, value)
+		}
+
+		rescored = append(rescored, Candidate{Key: candidate.Key, Score: value})
+	}
+
+	sort.SliceStable(rescored, func(i, j int) bool {
+		return rescored[i].Score > rescored[j].Score
+	})
+
+	if len(rescored) > topK {
+		rescored = rescored[:topK]
+	}
+
+	return rescored, nil
+}
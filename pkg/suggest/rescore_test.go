@@ -0,0 +1,92 @@
+package suggest
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestIDFRescoringOfSegmentedIndex(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	description := copyOnDiscIndex(t, descriptions[0])
+	defer os.RemoveAll(description.OutputPath)
+
+	service := NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+
+	documentFrequency := func(term string) int {
+		suggester := service.current.entries[description.Name].index.(*nGramIndex).suggester.(*nGramSuggester)
+		df, err := suggester.documentFrequency(term)
+		assert.NoError(t, err)
+
+		return df
+	}
+
+	df := documentFrequency("ves")
+
+	// the documents are written to a delta segment
+	err = service.UpdateDocuments(description.Name, []Document{
+		{Key: 100000, Value: "LADA VESTA"},
+		{Key: 100001, Value: "LADA VESTA SW"},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, df+2, documentFrequency("ves"))
+
+	searchConf, err := NewSearchConfig("lada vesta", 2, metric.IDFCosineMetric(), 0.5)
+	assert.NoError(t, err)
+
+	result, err := service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"LADA VESTA", "LADA VESTA SW"}, resultValues(result))
+	assert.InDelta(t, 1.0, result[0].Score, 1e-9)
+}
+
+func TestRescoringMetrics(t *testing.T) {
+	service, description := newTestService(t, "Mercedes-Benz\nMercedes-AMG\nMercedes-Maybach\nMercedes-Benz Vans\nBenz\nMersedes\n", nil)
+
+	searchConf, err := NewSearchConfig("mercedes benz", 3, metric.CosineMetric(), 0.3)
+	assert.NoError(t, err)
+
+	result, err := service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercedes-Benz", "Mercedes-Benz Vans", "Mercedes-AMG"}, resultValues(result))
+
+	// the n-grams of "mercedes" are common, so the rare ones of "benz" weigh more
+	searchConf, err = NewSearchConfig("mercedes benz", 3, metric.IDFCosineMetric(), 0.3)
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercedes-Benz", "Mercedes-Benz Vans", "Benz"}, resultValues(result))
+
+	searchConf, err = NewSearchConfig("mercedes benz", 1, metric.JaroWinklerMetric(), 0.3, WithExplain())
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercedes-Benz"}, resultValues(result))
+	assert.InDelta(t, metric.JaroWinklerSimilarity("mercedes benz", "mercedes-benz"), result[0].Score, 1e-9)
+	assert.Equal(t, result[0].Score, result[0].Explanation.MetricScore)
+	assert.Equal(t, "JaroWinkler", result[0].Explanation.Metric)
+
+	// the re-scored candidates are checked against the similarity of the query again
+	searchConf, err = NewSearchConfig("benz", 10, metric.IDFCosineMetric(), 0.5)
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Benz", "Mercedes-Benz"}, resultValues(result))
+
+	searchConf, err = NewSearchConfig("benz", 10, metric.JaroWinklerMetric(), 0.5)
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Benz"}, resultValues(result))
+}
@@ -1,6 +1,9 @@
 package suggest
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/suggest-go/suggest/pkg/merger"
 	"github.com/suggest-go/suggest/pkg/metric"
 )
@@ -29,3 +32,78 @@ func NewMetricScorer(metric metric.Metric, sizeA, sizeB int) Scorer {
 func (s *metricScorer) Score(candidate merger.MergeCandidate) float64 {
 	return 1 - s.metric.Distance(candidate.Overlap(), s.sizeA, s.sizeB)
 }
+
+// WeightFormula blends the score of a candidate with its document weight,
+// maxWeight is the biggest document weight of the dictionary
+type WeightFormula func(score, weight, maxWeight float64) float64
+
+// LinearWeightFormula returns a formula, that blends the score with the normalized weight
+// as (1 - alpha) * score + alpha * weight / maxWeight
+func LinearWeightFormula(alpha float64) WeightFormula {
+	return func(score, weight, maxWeight float64) float64 {
+		if maxWeight <= 0 {
+			return (1 - alpha) * score
+		}
+
+		return (1-alpha)*score + alpha*weight/maxWeight
+	}
+}
+
+// LogWeightFormula returns a formula, that blends the score with the log-normalized weight
+// as (1 - alpha) * score + alpha * log(1 + weight) / log(1 + maxWeight).
+// It suits better for the long-tailed weights such as popularity counters
+func LogWeightFormula(alpha float64) WeightFormula {
+	return func(score, weight, maxWeight float64) float64 {
+		if maxWeight <= 0 {
+			return (1 - alpha) * score
+		}
+
+		return (1-alpha)*score + alpha*math.Log1p(weight)/math.Log1p(maxWeight)
+	}
+}
+
+// GetWeightFormula returns the weight formula with the given name ("linear" or "log")
+// and the weight share alpha
+func GetWeightFormula(name string, alpha float64) (WeightFormula, error) {
+	if alpha < 0 || alpha > 1 {
+		return nil, fmt.Errorf("alpha should be in [0.0, 1.0]")
+	}
+
+	switch name {
+	case "linear":
+		return LinearWeightFormula(alpha), nil
+	case "log":
+		return LogWeightFormula(alpha), nil
+	default:
+		return nil, fmt.Errorf("weight formula %s is not supported", name)
+	}
+}
+
+type weightScorer struct {
+	scorer  Scorer
+	weights Weights
+	formula WeightFormula
+}
+
+// NewWeightScorer creates a new scorer that blends the score of the given scorer
+// with the document weight by using the formula
+func NewWeightScorer(scorer Scorer, weights Weights, formula WeightFormula) Scorer {
+	return &weightScorer{
+		scorer:  scorer,
+		weights: weights,
+		formula: formula,
+	}
+}
+
+// Score returns the score of the given candidate
+func (s *weightScorer) Score(candidate merger.MergeCandidate) float64 {
+	return s.formula(s.scorer.Score(candidate), s.weights.Get(candidate.Position()), s.weights.Max())
+}
+
+// constScorer scores all candidates equally
+type constScorer float64
+
+// Score returns the score of the given candidate
+func (s constScorer) Score(candidate merger.MergeCandidate) float64 {
+	return float64(s)
+}
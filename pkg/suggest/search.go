@@ -3,6 +3,7 @@ package suggest
 import (
 	"fmt"
 
+	"github.com/suggest-go/suggest/pkg/dictionary"
 	"github.com/suggest-go/suggest/pkg/metric"
 )
 
@@ -12,10 +13,11 @@ type SearchConfig struct {
 	topK       int
 	metric     metric.Metric
 	similarity float64
+	options    queryOptions
 }
 
 // NewSearchConfig returns new instance of SearchConfig
-func NewSearchConfig(query string, topK int, metric metric.Metric, similarity float64) (SearchConfig, error) {
+func NewSearchConfig(query string, topK int, metric metric.Metric, similarity float64, opts ...QueryOption) (SearchConfig, error) {
 	if topK <= 0 {
 		return SearchConfig{}, fmt.Errorf("topK should be greater or equal to 1")
 	}
@@ -24,10 +26,170 @@ func NewSearchConfig(query string, topK int, metric metric.Metric, similarity fl
 		return SearchConfig{}, fmt.Errorf("similarity shouble be in (0.0, 1.0]")
 	}
 
+	options := newQueryOptions(opts)
+
+	if err := options.validate(); err != nil {
+		return SearchConfig{}, err
+	}
+
 	return SearchConfig{
 		query:      query,
 		topK:       topK,
 		metric:     metric,
 		similarity: similarity,
+		options:    options,
 	}, nil
 }
+
+// QueryOption configures an optional ranking parameter of Suggest and Autocomplete queries
+type QueryOption func(options *queryOptions)
+
+// queryOptions holds the optional ranking parameters of a query
+type queryOptions struct {
+	weightFormula WeightFormula
+	ranking       AutocompleteRanking
+	filter        Filter
+	reranking     *EditDistanceReranking
+	remapping     *keyboardRemapping
+	wordMatching  WordMatching
+	matching      AutocompleteMatching
+	typos         int
+	explain       bool
+	phoneticBoost float64
+}
+
+// newQueryOptions applies the given list of options
+func newQueryOptions(opts []QueryOption) queryOptions {
+	options := queryOptions{}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// validate checks the consistency of the options
+func (o queryOptions) validate() error {
+	if o.reranking != nil && o.reranking.Distance == nil {
+		return fmt.Errorf("edit distance of the reranking should be set")
+	}
+
+	if o.wordMatching != "" {
+		if _, err := ParseWordMatching(string(o.wordMatching)); err != nil {
+			return err
+		}
+	}
+
+	if o.matching != "" {
+		if _, err := ParseAutocompleteMatching(string(o.matching)); err != nil {
+			return err
+		}
+	}
+
+	if o.typos < 0 {
+		return fmt.Errorf("number of autocomplete typos should be non-negative, got %d", o.typos)
+	}
+
+	if o.typos > 0 && o.matching != "" && o.matching != PrefixMatching {
+		return fmt.Errorf("autocomplete typos are supported only by %s matching", PrefixMatching)
+	}
+
+	if o.phoneticBoost < 0 || o.phoneticBoost > 1 {
+		return fmt.Errorf("phonetic boost should be in [0.0, 1.0], got %v", o.phoneticBoost)
+	}
+
+	// the explanation recomputes the n-gram overlap of the query, so it can't explain the candidates
+	// found by the words, by the phonetic keys or by a remapping of the query
+	if o.explain && (o.wordMatching != "" || o.phoneticBoost > 0 || o.remapping != nil) {
+		return fmt.Errorf("explanation is not supported along with word matching, phonetic boost or keyboard remapping")
+	}
+
+	if o.remapping != nil {
+		return o.remapping.validate()
+	}
+
+	return nil
+}
+
+// search calls the search function for the query, and also for its remappings if
+// the keyboard layouts are set. At most limit of the found candidates are returned along with
+// the queries, that have found each of them, the queries are nil if the keyboard layouts aren't set
+func (o queryOptions) search(
+	query string,
+	limit int,
+	scored bool,
+	search func(query string) ([]Candidate, error),
+) ([]Candidate, map[dictionary.Key][]string, error) {
+	if o.remapping == nil {
+		candidates, err := search(query)
+
+		return candidates, nil, err
+	}
+
+	search, queries := recordQueries(search)
+	candidates, err := o.remapping.search(query, limit, scored, search)
+
+	return candidates, queries, err
+}
+
+// WithWeightFormula makes a query rank candidates by blending their scores
+// with the document weights through the given formula
+func WithWeightFormula(formula WeightFormula) QueryOption {
+	return func(options *queryOptions) {
+		options.weightFormula = formula
+	}
+}
+
+// WithFilter restricts a query to the documents, that satisfy each of the given clauses.
+// The filter is applied to the posting lists during the search, so the query still
+// returns the requested number of candidates if there are enough matching documents
+func WithFilter(clauses ...FilterClause) QueryOption {
+	return func(options *queryOptions) {
+		options.filter = append(options.filter, clauses...)
+	}
+}
+
+// AutocompleteRanking tells how Autocomplete chooses the candidates to return
+type AutocompleteRanking string
+
+const (
+	// FirstFoundRanking returns the first found candidates, this is the fastest way
+	FirstFoundRanking AutocompleteRanking = "first"
+	// LengthRanking prefers the shortest candidates, i.e. the closest ones to the query
+	LengthRanking AutocompleteRanking = "length"
+	// WeightRanking prefers the heaviest candidates. The weight is blended with the length score
+	// through the weight formula of the query, by default only the weight is taken into account
+	WeightRanking AutocompleteRanking = "weight"
+)
+
+// ParseAutocompleteRanking returns the autocomplete ranking with the given name
+func ParseAutocompleteRanking(name string) (AutocompleteRanking, error) {
+	switch ranking := AutocompleteRanking(name); ranking {
+	case FirstFoundRanking, LengthRanking, WeightRanking:
+		return ranking, nil
+	default:
+		return "", fmt.Errorf("autocomplete ranking %s is not supported", name)
+	}
+}
+
+// WithAutocompleteRanking sets the way Autocomplete chooses the candidates to return.
+// If the ranking is not set, WeightRanking is used for a query with a weight formula
+// and FirstFoundRanking otherwise
+func WithAutocompleteRanking(ranking AutocompleteRanking) QueryOption {
+	return func(options *queryOptions) {
+		options.ranking = ranking
+	}
+}
+
+// autocompleteRanking returns the autocomplete ranking of the query
+func (o queryOptions) autocompleteRanking() AutocompleteRanking {
+	switch {
+	case o.ranking != "":
+		return o.ranking
+	case o.weightFormula != nil:
+		return WeightRanking
+	default:
+		return FirstFoundRanking
+	}
+}
@@ -0,0 +1,36 @@
+package suggest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestInterruptedSearch(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	service := NewService()
+	assert.NoError(t, service.AddOnDiscIndex(descriptions[0]))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	searchConf, err := NewSearchConfig("Nissan", 5, metric.CosineMetric(), 0.3)
+	assert.NoError(t, err)
+
+	result, err := service.Suggest(ctx, descriptions[0].Name, searchConf)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Empty(t, result)
+
+	result, err = service.Autocomplete(ctx, descriptions[0].Name, "Nissan", 5)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Empty(t, result)
+
+	result, err = service.Autocomplete(context.Background(), descriptions[0].Name, "Nissan", 5)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, result)
+}
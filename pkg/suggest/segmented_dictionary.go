@@ -0,0 +1,168 @@
+package suggest
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+// OpenSegmentedDictionary opens the dictionaries of all segments of the on-disc index
+// with the given description
+func OpenSegmentedDictionary(directory store.Directory, description IndexDescription) (dictionary.Dictionary, error) {
+	return openSegmentedDictionary(directory, description, description.GetSegmentDictionaryFile, false)
+}
+
+// OpenSegmentedPayloads opens the document payloads of all segments of the on-disc index
+// with the given description. The documents without a payload are absent in the returned dictionary
+func OpenSegmentedPayloads(directory store.Directory, description IndexDescription) (dictionary.Dictionary, error) {
+	return openSegmentedDictionary(directory, description, description.GetSegmentPayloadFile, true)
+}
+
+// openSegmentedDictionary opens the cdb dictionaries of all segments, the name of the segment dictionary
+// is resolved by fileName. If optional is true, a missing dictionary is treated as an empty one
+func openSegmentedDictionary(
+	directory store.Directory,
+	description IndexDescription,
+	fileName func(segment string) string,
+	optional bool,
+) (dictionary.Dictionary, error) {
+	infos, err := index.ReadSegmentInfos(directory, description.Name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read segments: %w", err)
+	}
+
+	open := func(segment string) (dictionary.Dictionary, error) {
+		path := fileName(segment)
+
+		if _, err := os.Stat(path); optional && os.IsNotExist(err) {
+			return dictionary.NewInMemoryDictionary(nil), nil
+		}
+
+		return dictionary.OpenCDBDictionary(path)
+	}
+
+	if len(infos.Segments) == 1 && infos.Segments[0].DelGen == 0 {
+		return open(infos.Segments[0].Name)
+	}
+
+	dict := &segmentedDictionary{
+		segments:  make([]dictionary.Dictionary, 0, len(infos.Segments)),
+		deletions: make([]*roaring.Bitmap, 0, len(infos.Segments)),
+		size:      -1,
+	}
+
+	for _, info := range infos.Segments {
+		segment, err := open(info.Name)
+
+		if err != nil {
+			_ = dict.Close()
+			return nil, fmt.Errorf("failed to open dictionary of segment %s: %w", info.Name, err)
+		}
+
+		deletions, err := index.ReadDeletions(directory, info)
+
+		if err != nil {
+			_ = closeDictionary(segment)
+			_ = dict.Close()
+			return nil, err
+		}
+
+		dict.segments = append(dict.segments, segment)
+		dict.deletions = append(dict.deletions, deletions)
+	}
+
+	return dict, nil
+}
+
+// segmentedDictionary implements Dictionary for a list of index segments,
+// where each key is alive only in one segment
+type segmentedDictionary struct {
+	segments  []dictionary.Dictionary
+	deletions []*roaring.Bitmap
+	lock      sync.Mutex
+	size      int
+}
+
+// Get returns value associated with a particular key
+func (d *segmentedDictionary) Get(key dictionary.Key) (dictionary.Value, error) {
+	for i := len(d.segments) - 1; i >= 0; i-- {
+		if d.deletions[i].Contains(key) {
+			continue
+		}
+
+		value, err := d.segments[i].Get(key)
+
+		if err != nil {
+			return dictionary.NilValue, err
+		}
+
+		if value != dictionary.NilValue {
+			return value, nil
+		}
+	}
+
+	return dictionary.NilValue, nil
+}
+
+// Size returns the size of the dictionary
+func (d *segmentedDictionary) Size() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	if d.size >= 0 {
+		return d.size
+	}
+
+	size := 0
+
+	_ = d.Iterate(func(key dictionary.Key, value dictionary.Value) error {
+		size++
+		return nil
+	})
+
+	d.size = size
+
+	return size
+}
+
+// Iterate walks through each alive kv pair segment by segment,
+// so the keys are sorted only within a segment
+func (d *segmentedDictionary) Iterate(iterator dictionary.Iterator) error {
+	for i, segment := range d.segments {
+		deletions := d.deletions[i]
+
+		err := segment.Iterate(func(key dictionary.Key, value dictionary.Value) error {
+			if deletions.Contains(key) {
+				return nil
+			}
+
+			return iterator(key, value)
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Close closes the dictionaries of all segments.
+// The dictionary must not be used after this call
+func (d *segmentedDictionary) Close() error {
+	var err error
+
+	for _, segment := range d.segments {
+		if cErr := closeDictionary(segment); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+
+	return err
+}
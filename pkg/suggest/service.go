@@ -2,32 +2,46 @@
 package suggest
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 
 	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/store"
 )
 
+// ErrDictionaryNotFound is returned by a search in a dictionary, that the Service doesn't manage
+var ErrDictionaryNotFound = errors.New("dictionary is not found")
+
 // ResultItem represents element of top-k similar strings in dictionary for given query
 type ResultItem struct {
 	// Score is a float64 value of a candidate
 	Score float64
 	// Value is a string value of candidate
 	Value string
+	// Payload is a JSON object stored along with the candidate, if any
+	Payload json.RawMessage `json:",omitempty"`
+	// Highlights are the ranges of Value, that match the query
+	Highlights []Highlight `json:",omitempty"`
+	// Explanation describes how Score has been computed, it is set only for a query with WithExplain
+	Explanation *Explanation `json:",omitempty"`
 }
 
 // Service provides methods for autocomplete and topK approximate string search
 type Service struct {
 	sync.RWMutex
-	indexes      map[string]NGramIndex
-	dictionaries map[string]dictionary.Dictionary
+	current *generation
+	// writeLock serializes runtime changes of on-disc indexes
+	writeLock sync.Mutex
 }
 
 // NewService creates an empty SuggestService
 func NewService() *Service {
 	return &Service{
-		indexes:      make(map[string]NGramIndex),
-		dictionaries: make(map[string]dictionary.Dictionary),
+		current: newGeneration(),
 	}
 }
 
@@ -42,36 +56,148 @@ func (s *Service) AddIndexByDescription(description IndexDescription) error {
 
 // AddRunTimeIndex adds a new RAM search index with the given description
 func (s *Service) AddRunTimeIndex(description IndexDescription) error {
-	dict, err := dictionary.OpenRAMDictionary(description.GetSourcePath())
+	entry, err := openRunTimeIndex(description)
 
 	if err != nil {
-		return fmt.Errorf("failed to create RAMDriver builder: %w", err)
+		return err
 	}
 
-	builder, err := NewRAMBuilder(dict, description)
+	s.update(func(next *generation) {
+		next.set(description.Name, entry)
+		delete(next.descriptions, description.Name)
+	})
+
+	return nil
+}
+
+// AddOnDiscIndex adds a new DISC search index with the given description
+func (s *Service) AddOnDiscIndex(description IndexDescription) error {
+	entry, err := openOnDiscIndex(description)
 
 	if err != nil {
-		return fmt.Errorf("failed to create RAMDriver builder: %w", err)
+		return err
 	}
 
-	return s.AddIndex(description.Name, dict, builder)
+	s.update(func(next *generation) {
+		next.set(description.Name, entry)
+		next.descriptions[description.Name] = description
+	})
+
+	return nil
 }
 
-// AddOnDiscIndex adds a new DISC search index with the given description
-func (s *Service) AddOnDiscIndex(description IndexDescription) error {
-	dict, err := dictionary.OpenCDBDictionary(description.GetDictionaryFile())
+// Reindex opens the search indexes for the given descriptions and replaces all managed
+// indexes with them at once. If any of the indexes fails to open, the managed indexes are left
+// untouched. The resources of the replaced indexes are released after all queries that use them are done
+func (s *Service) Reindex(descriptions []IndexDescription) error {
+	next := newGeneration()
+
+	for _, description := range descriptions {
+		if _, ok := next.entries[description.Name]; ok {
+			next.release()
+			return fmt.Errorf("dictionary %s is described more than once", description.Name)
+		}
+
+		entry, err := openIndex(description)
+
+		if err != nil {
+			next.release()
+			return fmt.Errorf("failed to open dictionary %s: %w", description.Name, err)
+		}
+
+		next.set(description.Name, entry)
+
+		if description.Driver != RAMDriver {
+			next.descriptions[description.Name] = description
+		}
+	}
+
+	s.Lock()
+	prev := s.current
+	s.current = next
+	s.Unlock()
+
+	go prev.retire()
+
+	return nil
+}
+
+// UpdateDocuments adds the given documents to the on-disc index with the name dictName,
+// the documents with already existing keys are replaced. Note that Compact renumbers the documents,
+// so a key known before a compaction may refer to another document after it, see Compact
+func (s *Service) UpdateDocuments(dictName string, docs []Document) error {
+	return s.applyChanges(dictName, docs, nil)
+}
+
+// DeleteDocuments deletes the documents with the given keys from the on-disc index with the name dictName.
+// The keys are the ones of the current index, the same as for UpdateDocuments, see Compact
+func (s *Service) DeleteDocuments(dictName string, keys []dictionary.Key) error {
+	return s.applyChanges(dictName, nil, keys)
+}
+
+// applyChanges persists the given changes as a new index segment and reopens the index.
+// The word and the phonetic indexes are derived from the ones of the current index by applying
+// the changes, unless the on-disc index has been changed by another writer since it was opened
+func (s *Service) applyChanges(dictName string, docs []Document, deletes []dictionary.Key) error {
+	s.writeLock.Lock()
+	defer s.writeLock.Unlock()
+
+	current, release := s.acquire()
+	defer release()
+
+	description, ok := current.descriptions[dictName]
+
+	if !ok {
+		return fmt.Errorf("given dictionary %s is not exists or is not an on-disc one", dictName)
+	}
+
+	directory, err := store.NewFSDirectory(description.GetIndexPath())
+
+	if err != nil {
+		return fmt.Errorf("failed to create a fs directory: %w", err)
+	}
+
+	lock, err := LockIndex(directory, description)
+
+	if err != nil {
+		return err
+	}
+
+	defer lock.Close()
+
+	infos, err := index.ReadSegmentInfos(directory, description.Name)
 
 	if err != nil {
-		return fmt.Errorf("failed to create CDB dictionary: %w", err)
+		return fmt.Errorf("failed to read index segments: %w", err)
 	}
 
-	builder, err := NewFSBuilder(description)
+	if err := updateIndex(directory, description, docs, deletes); err != nil {
+		return fmt.Errorf("failed to update index: %w", err)
+	}
+
+	entry, err := openOnDiscEntry(description)
+
+	if err != nil {
+		return err
+	}
+
+	if prev := current.entries[dictName]; prev.segments == infos.Generation {
+		err = entry.updateTextIndexes(prev, docs, deletes)
+	} else {
+		err = entry.openTextIndexes(directory, description)
+	}
 
 	if err != nil {
-		return fmt.Errorf("failed to open FS inverted index: %w", err)
+		entry.close()
+		return err
 	}
 
-	return s.AddIndex(description.Name, dict, builder)
+	s.update(func(next *generation) {
+		next.set(description.Name, entry)
+		next.descriptions[description.Name] = description
+	})
+
+	return nil
 }
 
 // AddIndex adds an index with the given name, dictionary and builder
@@ -82,92 +208,362 @@ func (s *Service) AddIndex(name string, dict dictionary.Dictionary, builder Buil
 		return fmt.Errorf("failed to build NGramIndex: %w", err)
 	}
 
-	s.Lock()
-	s.indexes[name] = nGramIndex
-	s.dictionaries[name] = dict
-	s.Unlock()
+	s.update(func(next *generation) {
+		next.set(name, newIndexEntry(nGramIndex, dict, nil, nil, nil))
+	})
 
 	return nil
 }
 
 // GetDictionaries returns the managed list of dictionaries
 func (s *Service) GetDictionaries() []string {
-	names := make([]string, 0, len(s.dictionaries))
+	s.RLock()
+	defer s.RUnlock()
 
-	for name := range s.dictionaries {
+	names := make([]string, 0, len(s.current.entries))
+
+	for name := range s.current.entries {
 		names = append(names, name)
 	}
 
 	return names
 }
 
-// Suggest returns Top-k approximate strings for the given query in the dict
-func (s *Service) Suggest(dictName string, config SearchConfig) ([]ResultItem, error) {
+// update makes a copy of the current generation, applies the given change to it
+// and makes it current
+func (s *Service) update(change func(next *generation)) {
+	s.Lock()
+	prev := s.current
+	next := prev.copy()
+	change(next)
+	s.current = next
+	s.Unlock()
+
+	go prev.retire()
+}
+
+// acquire returns the current generation, that can't be released until the returned
+// release function is called
+func (s *Service) acquire() (*generation, func()) {
 	s.RLock()
-	index, okIndex := s.indexes[dictName]
-	dict, okDict := s.dictionaries[dictName]
+	current := s.current
+	current.inFlight.Add(1)
 	s.RUnlock()
 
-	if !okDict || !okIndex {
-		return nil, fmt.Errorf("given dictionary %s is not exists", dictName)
+	return current, current.inFlight.Done
+}
+
+// Suggest returns Top-k approximate strings for the given query in the dict.
+// If the context is done before the search is finished, the candidates found so far
+// are returned along with the context error
+func (s *Service) Suggest(ctx context.Context, dictName string, config SearchConfig) ([]ResultItem, error) {
+	current, release := s.acquire()
+	defer release()
+
+	entry, ok := current.entries[dictName]
+
+	if !ok {
+		return nil, fmt.Errorf("given dictionary %s is not exists: %w", dictName, ErrDictionaryNotFound)
 	}
 
-	candidates, err := index.Suggest(
-		config.query,
-		config.similarity,
-		config.metric,
-		newFuzzyCollectorManager(config.topK),
-	)
+	return entry.suggest(ctx, config)
+}
 
-	if err != nil {
+// suggest returns Top-k approximate strings for the given query in the index of the entry
+func (e *indexEntry) suggest(ctx context.Context, config SearchConfig) ([]ResultItem, error) {
+	topK := config.topK
+	reranking := config.options.reranking
+
+	if reranking != nil {
+		topK = reranking.limit(topK)
+	}
+
+	collectLimit := topK
+	rescoring := isRescoring(config.metric)
+
+	if rescoring {
+		collectLimit = topK * rescoreCandidatesFactor
+	}
+
+	factory := newFuzzyCollectorManager(collectLimit)
+
+	if config.options.weightFormula != nil {
+		factory = newWeightedCollectorManager(collectLimit, e.weights, config.options.weightFormula)
+	}
+
+	factory = e.filtered(factory, config.options.filter)
+	candidates, queries, err := config.options.search(config.query, topK, true, func(query string) ([]Candidate, error) {
+		var (
+			candidates []Candidate
+			err        error
+		)
+
+		if config.options.wordMatching != "" {
+			candidates, err = e.suggestWords(ctx, query, config, topK)
+		} else {
+			candidates, err = e.index.Suggest(ctx, query, config.similarity, config.metric, factory)
+
+			if rescoring && (err == nil || ctx.Err() != nil) {
+				var rescoreErr error
+
+				if candidates, rescoreErr = e.rescore(query, candidates, config, topK); rescoreErr != nil {
+					return nil, rescoreErr
+				}
+			}
+		}
+
+		if config.options.phoneticBoost > 0 && (err == nil || ctx.Err() != nil) {
+			var blendErr error
+
+			if candidates, blendErr = e.blendPhonetic(query, candidates, config, topK); blendErr != nil {
+				return nil, blendErr
+			}
+		}
+
+		return candidates, err
+	})
+
+	result, err := e.partialResultItems(ctx, config.query, queries, candidates, err, true)
+
+	if config.options.explain && result != nil {
+		if explainErr := e.explain(config, candidates, result); explainErr != nil {
+			return nil, explainErr
+		}
+	}
+
+	if reranking != nil && result != nil {
+		result = reranking.rerank(config.query, config.options.remapping, result, config.topK)
+	}
+
+	return result, err
+}
+
+// Autocomplete returns limit candidates where the query string is a prefix of each candidate,
+// use WithAutocompleteMatching to match the query in the middle of a candidate and WithAutocompleteTypos to tolerate typos.
+// By default the first found candidates are returned, use WithAutocompleteRanking to rank them.
+// If the context is done before the search is finished, the candidates found so far
+// are returned along with the context error
+func (s *Service) Autocomplete(ctx context.Context, dictName string, query string, limit int, opts ...QueryOption) ([]ResultItem, error) {
+	current, release := s.acquire()
+	defer release()
+
+	entry, ok := current.entries[dictName]
+
+	if !ok {
+		return nil, fmt.Errorf("given dictionary %s is not exists: %w", dictName, ErrDictionaryNotFound)
+	}
+
+	options := newQueryOptions(opts)
+
+	if err := options.validate(); err != nil {
 		return nil, err
 	}
 
-	l := len(candidates)
-	result := make([]ResultItem, 0, l)
+	ranking := options.autocompleteRanking()
+	collectLimit := limit
 
-	for _, candidate := range candidates {
-		value, err := dict.Get(candidate.Key)
-		if err != nil {
-			return nil, err
+	if options.typos > 0 {
+		collectLimit = limit * typoCandidatesFactor
+	}
+
+	var factory CollectorManagerFactory
+
+	switch ranking {
+	case FirstFoundRanking:
+		factory = newFirstKCollectorManager(collectLimit)
+	case LengthRanking:
+		factory = newFuzzyCollectorManager(collectLimit)
+	case WeightRanking:
+		formula := options.weightFormula
+
+		if formula == nil {
+			formula = LinearWeightFormula(1)
+		}
+
+		factory = newWeightedCollectorManager(collectLimit, entry.weights, formula)
+	default:
+		return nil, fmt.Errorf("autocomplete ranking %s is not supported", ranking)
+	}
+
+	scored := ranking != FirstFoundRanking
+	candidates, queries, err := options.search(query, limit, scored, func(query string) ([]Candidate, error) {
+		if options.typos > 0 {
+			return autocompleteWithTypos(ctx, entry.index, entry.dictionary, entry.analyze, query, options.typos, entry.filtered(factory, options.filter))
 		}
 
-		result = append(result, ResultItem{candidate.Score, value})
+		// the infix candidates are verified before the filter, so the filter stays the outermost collector manager
+		infixFactory := entry.filtered(entry.verified(factory, query, options.matching), options.filter)
+
+		return autocompleteWithMatching(ctx, entry.index, query, options.matching, entry.filtered(factory, options.filter), infixFactory)
+	})
+
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
 	}
 
-	return result, nil
+	return entry.partialResultItems(ctx, query, queries, candidates, err, scored)
 }
 
-// Autocomplete returns limit candidates where the query string is a prefix of each candidate
-func (s *Service) Autocomplete(dictName string, query string, limit int) ([]ResultItem, error) {
-	s.RLock()
-	index, okIndex := s.indexes[dictName]
-	dict, okDict := s.dictionaries[dictName]
-	s.RUnlock()
+// openIndex opens a search index with its stored data by the given description
+func openIndex(description IndexDescription) (*indexEntry, error) {
+	if description.Driver == RAMDriver {
+		return openRunTimeIndex(description)
+	}
+
+	return openOnDiscIndex(description)
+}
+
+// openRunTimeIndex builds a RAM search index with its stored data by the given description
+func openRunTimeIndex(description IndexDescription) (*indexEntry, error) {
+	docs, err := ReadSource(description)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RAMDriver builder: %w", err)
+	}
+
+	values := make([]dictionary.Value, 0, len(docs))
+	payloads := make([]dictionary.Value, 0, len(docs))
+	weights := documentWeights(docs)
+
+	for _, doc := range docs {
+		values = append(values, doc.Value)
+		payload := dictionary.NilValue
+
+		if len(doc.Payload) > 0 {
+			payload = dictionary.Value(doc.Payload)
+		}
+
+		payloads = append(payloads, payload)
+	}
+
+	dict := dictionary.NewInMemoryDictionary(values)
+	builder, err := NewRAMBuilder(dict, description)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create RAMDriver builder: %w", err)
+	}
+
+	nGramIndex, err := builder.Build()
 
-	if !okDict || !okIndex {
-		return nil, fmt.Errorf("given dictionary %s is not exists", dictName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build NGramIndex: %w", err)
 	}
 
-	candidates, err := index.Autocomplete(
-		query,
-		newFirstKCollectorManager(limit),
+	entry := newIndexEntry(
+		nGramIndex,
+		dict,
+		NewWeights(weights),
+		dictionary.NewInMemoryDictionary(payloads),
+		NewAttributes(docs),
 	)
 
+	if entry.highlighter, err = newHighlighter(description); err != nil {
+		return nil, fmt.Errorf("failed to create highlighter: %w", err)
+	}
+
+	if entry.verifier, err = newCandidateVerifier(description); err != nil {
+		return nil, fmt.Errorf("failed to create candidate verifier: %w", err)
+	}
+
+	if err := entry.buildTextIndexes(description); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// openOnDiscIndex opens a DISC search index with its stored data by the given description
+func openOnDiscIndex(description IndexDescription) (*indexEntry, error) {
+	entry, err := openOnDiscEntry(description)
+
 	if err != nil {
 		return nil, err
 	}
 
-	result := make([]ResultItem, 0, len(candidates))
+	directory, err := store.NewFSDirectory(description.GetIndexPath())
 
-	for _, candidate := range candidates {
-		value, err := dict.Get(candidate.Key)
-		if err != nil {
-			return nil, err
-		}
+	if err != nil {
+		entry.close()
+		return nil, fmt.Errorf("failed to create a fs directory: %w", err)
+	}
+
+	if err := entry.openTextIndexes(directory, description); err != nil {
+		entry.close()
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// openOnDiscEntry opens a DISC search index with its stored data by the given description,
+// the word and the phonetic indexes are left to be built by the caller
+func openOnDiscEntry(description IndexDescription) (*indexEntry, error) {
+	directory, err := store.NewFSDirectory(description.GetIndexPath())
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create a fs directory: %w", err)
+	}
+
+	// the generation is read before the index, so a concurrent change makes the entry look outdated
+	infos, err := index.ReadSegmentInfos(directory, description.Name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read index segments: %w", err)
+	}
+
+	weights, err := OpenWeights(directory, description)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document weights: %w", err)
+	}
+
+	attributes, err := OpenAttributes(directory, description)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open document attributes: %w", err)
+	}
+
+	dict, err := OpenSegmentedDictionary(directory, description)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CDB dictionary: %w", err)
+	}
+
+	payloads, err := OpenSegmentedPayloads(directory, description)
+
+	if err != nil {
+		_ = closeDictionary(dict)
+		return nil, fmt.Errorf("failed to open document payloads: %w", err)
+	}
+
+	builder, err := NewBuilder(directory, description)
+
+	if err != nil {
+		_ = closeDictionary(dict)
+		_ = closeDictionary(payloads)
+		return nil, fmt.Errorf("failed to open FS inverted index: %w", err)
+	}
+
+	nGramIndex, err := builder.Build()
+
+	if err != nil {
+		_ = closeDictionary(dict)
+		_ = closeDictionary(payloads)
+		return nil, fmt.Errorf("failed to build NGramIndex: %w", err)
+	}
+
+	entry := newIndexEntry(nGramIndex, dict, weights, payloads, attributes)
+	entry.segments = infos.Generation
+
+	if entry.highlighter, err = newHighlighter(description); err != nil {
+		entry.close()
+		return nil, fmt.Errorf("failed to create highlighter: %w", err)
+	}
 
-		result = append(result, ResultItem{0, value})
+	if entry.verifier, err = newCandidateVerifier(description); err != nil {
+		entry.close()
+		return nil, fmt.Errorf("failed to create candidate verifier: %w", err)
 	}
 
-	return result, nil
+	return entry, nil
 }
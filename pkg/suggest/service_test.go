@@ -1,11 +1,18 @@
 package suggest
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/dictionary"
 	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/store"
 )
 
 func TestConcurrencyOnDisc(t *testing.T) {
@@ -62,7 +69,7 @@ func testConcurrency(t *testing.T, driver Driver) {
 		for i := 0; i < len(expectedValues); i++ {
 			searchConf, _ := NewSearchConfig(wordsList[i], 5, metric.CosineMetric(), 0.7)
 
-			result, err := service.Suggest(description.Name, searchConf)
+			result, err := service.Suggest(context.Background(), description.Name, searchConf)
 			assert.NoError(t, err)
 
 			actual := make([]string, 0, len(result))
@@ -78,3 +85,288 @@ func testConcurrency(t *testing.T, driver Driver) {
 
 	wg.Wait()
 }
+
+func TestUpdateOnDiscIndex(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	description := copyOnDiscIndex(t, descriptions[0])
+	defer os.RemoveAll(description.OutputPath)
+
+	service := NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+
+	suggest := func(query string) []string {
+		searchConf, err := NewSearchConfig(query, 5, metric.CosineMetric(), 0.7)
+		assert.NoError(t, err)
+
+		result, err := service.Suggest(context.Background(), description.Name, searchConf)
+		assert.NoError(t, err)
+
+		return resultValues(result)
+	}
+
+	assert.Equal(t, []string{"NISSAN MARCH"}, suggest("Nissan March"))
+
+	err = service.UpdateDocuments(description.Name, []Document{
+		{Key: 100000, Value: "LADA VESTA"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"LADA VESTA"}, suggest("Lada Vesta"))
+
+	dict := service.current.entries[description.Name].dictionary
+	key := dictionary.Key(0)
+
+	assert.NoError(t, dict.Iterate(func(k dictionary.Key, value dictionary.Value) error {
+		if value == "NISSAN MARCH" {
+			key = k
+		}
+
+		return nil
+	}))
+
+	err = service.UpdateDocuments(description.Name, []Document{
+		{Key: key, Value: "NISSAN MARCH II"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NISSAN MARCH II"}, suggest("Nissan March"))
+
+	assert.NoError(t, service.DeleteDocuments(description.Name, []dictionary.Key{key, 100000}))
+	assert.Equal(t, []string{}, suggest("Nissan March II"))
+	assert.Equal(t, []string{}, suggest("Lada Vesta"))
+
+	// the changes should survive the index reopening
+	service = NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+	assert.Equal(t, []string{}, suggest("Lada Vesta"))
+	assert.Equal(t, []string{"TOYOTA COROLLA"}, suggest("Toyota Corolla"))
+}
+
+func TestReindex(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	service := NewService()
+	assert.NoError(t, service.Reindex(descriptions[:1]))
+	assert.Equal(t, []string{"cars"}, service.GetDictionaries())
+
+	searchConf, err := NewSearchConfig("Nissan March", 5, metric.CosineMetric(), 0.7)
+	assert.NoError(t, err)
+
+	expected := []ResultItem{{Score: 1, Value: "NISSAN MARCH", Highlights: []Highlight{{0, 6}, {7, 12}}}}
+
+	result, err := service.Suggest(context.Background(), "cars", searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	broken := descriptions[0]
+	broken.Name = "broken"
+	broken.OutputPath = "not_exists"
+
+	assert.Error(t, service.Reindex([]IndexDescription{descriptions[0], broken}))
+	assert.Error(t, service.Reindex([]IndexDescription{descriptions[0], descriptions[0]}))
+
+	// the failed reindex should not touch the current indexes
+	assert.Equal(t, []string{"cars"}, service.GetDictionaries())
+
+	result, err = service.Suggest(context.Background(), "cars", searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+
+	ram := descriptions[0]
+	ram.Name = "cars_ram"
+	ram.Driver = RAMDriver
+
+	assert.NoError(t, service.Reindex([]IndexDescription{ram}))
+	assert.Equal(t, []string{"cars_ram"}, service.GetDictionaries())
+
+	_, err = service.Suggest(context.Background(), "cars", searchConf)
+	assert.Error(t, err)
+}
+
+func TestReleasePreviousGeneration(t *testing.T) {
+	prevIndex, prevDict := newClosableIndex(), &closableDictionary{closed: make(chan struct{})}
+	service := NewService()
+
+	assert.NoError(t, service.AddIndex("test", prevDict, &staticBuilder{prevIndex}))
+
+	_, release := service.acquire()
+	assert.NoError(t, service.AddIndex("test", &closableDictionary{closed: make(chan struct{})}, &staticBuilder{newClosableIndex()}))
+
+	select {
+	case <-prevIndex.closed:
+		assert.Fail(t, "the index has been closed while a query is in flight")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	release()
+
+	for _, closed := range []chan struct{}{prevIndex.closed, prevDict.closed} {
+		select {
+		case <-closed:
+		case <-time.After(time.Second):
+			assert.Fail(t, "the previous generation has not been released")
+		}
+	}
+}
+
+func TestKeepSharedIndexOfOlderGeneration(t *testing.T) {
+	shared, sharedDict := newClosableIndex(), &closableDictionary{closed: make(chan struct{})}
+	service := NewService()
+
+	assert.NoError(t, service.AddIndex("test", sharedDict, &staticBuilder{shared}))
+
+	// the first generation holding the shared index is still in use
+	_, releaseFirst := service.acquire()
+	assert.NoError(t, service.AddIndex("other", &closableDictionary{closed: make(chan struct{})}, &staticBuilder{newClosableIndex()}))
+
+	// the second generation, that shares the index, is retired by the replacement
+	_, releaseSecond := service.acquire()
+	assert.NoError(t, service.AddIndex("test", &closableDictionary{closed: make(chan struct{})}, &staticBuilder{newClosableIndex()}))
+	releaseSecond()
+
+	select {
+	case <-shared.closed:
+		assert.Fail(t, "the shared index has been closed while a query of an older generation is in flight")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	releaseFirst()
+
+	select {
+	case <-shared.closed:
+	case <-time.After(time.Second):
+		assert.Fail(t, "the shared index has not been released")
+	}
+}
+
+// closableIndex is a NGramIndex that tracks its closing
+type closableIndex struct {
+	NGramIndex
+	closed chan struct{}
+}
+
+func newClosableIndex() *closableIndex {
+	return &closableIndex{closed: make(chan struct{})}
+}
+
+func (c *closableIndex) Close() error {
+	close(c.closed)
+	return nil
+}
+
+// closableDictionary is a Dictionary that tracks its closing
+type closableDictionary struct {
+	dictionary.Dictionary
+	closed chan struct{}
+}
+
+func (c *closableDictionary) Close() error {
+	close(c.closed)
+	return nil
+}
+
+// staticBuilder is a Builder that returns the given index
+type staticBuilder struct {
+	index NGramIndex
+}
+
+func (b *staticBuilder) Build() (NGramIndex, error) {
+	return b.index, nil
+}
+
+// resultValues returns the values of the given result items
+func resultValues(result []ResultItem) []string {
+	values := make([]string, 0, len(result))
+
+	for _, item := range result {
+		values = append(values, item.Value)
+	}
+
+	return values
+}
+
+// copyOnDiscIndex copies the on-disc index of the description to a temporary directory
+func copyOnDiscIndex(t *testing.T, description IndexDescription) IndexDescription {
+	outputPath, err := ioutil.TempDir("", "suggest")
+	assert.NoError(t, err)
+
+	for _, ext := range []string{"cdb", "hd", "dl"} {
+		data, err := ioutil.ReadFile(fmt.Sprintf("%s/%s.%s", description.GetIndexPath(), description.Name, ext))
+		assert.NoError(t, err)
+
+		err = ioutil.WriteFile(fmt.Sprintf("%s/%s.%s", outputPath, description.Name, ext), data, 0644)
+		assert.NoError(t, err)
+	}
+
+	description.OutputPath = outputPath
+
+	return description
+}
+
+// newTestService adds the RAM index of the given values, one per line, to a new service.
+// The index is described by the first test description changed with configure
+func newTestService(t *testing.T, values string, configure func(description *IndexDescription)) (*Service, IndexDescription) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	source, err := ioutil.TempFile("", "suggest")
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = os.Remove(source.Name())
+	})
+
+	_, err = source.WriteString(values)
+	assert.NoError(t, err)
+	assert.NoError(t, source.Close())
+
+	description := descriptions[0]
+	description.Driver = RAMDriver
+	description.SourcePath = source.Name()
+
+	if configure != nil {
+		configure(&description)
+	}
+
+	service := NewService()
+	assert.NoError(t, service.AddRunTimeIndex(description))
+
+	return service, description
+}
+
+// buildTestIndex builds the on-disc index of the given source in a temporary directory.
+// The index is described by the first test description changed with configure
+func buildTestIndex(t *testing.T, source string, configure func(description *IndexDescription)) (store.Directory, IndexDescription) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	outputPath, err := ioutil.TempDir("", "suggest")
+	assert.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = os.RemoveAll(outputPath)
+	})
+
+	description := descriptions[0]
+	description.SourcePath = outputPath + "/source.dict"
+	description.OutputPath = outputPath
+
+	if configure != nil {
+		configure(&description)
+	}
+
+	assert.NoError(t, ioutil.WriteFile(description.SourcePath, []byte(source), 0644))
+
+	directory, err := store.NewFSDirectory(outputPath)
+	assert.NoError(t, err)
+
+	dict, err := BuildDictionary(directory, description)
+	assert.NoError(t, err)
+
+	tokenizer, err := BuildIndexTokenizer(directory, dict, description)
+	assert.NoError(t, err)
+	assert.NoError(t, Index(directory, dict, description.GetWriterConfig(), tokenizer))
+
+	return directory, description
+}
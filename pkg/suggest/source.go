@@ -0,0 +1,264 @@
+package suggest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/suggest-go/suggest/pkg/dictionary"
+)
+
+// SourceReader reads documents from a dictionary source.
+// The documents are keyed by their line numbers starting from 0
+type SourceReader interface {
+	// Iterate calls fn on each document of the source
+	Iterate(fn func(doc Document) error) error
+}
+
+// NewSourceReader creates a new instance of SourceReader for a PlainFormat source, where each
+// line is a document value as is
+func NewSourceReader(reader io.Reader) SourceReader {
+	return &lineSourceReader{
+		reader: reader,
+		parse: func(line string) (Document, error) {
+			return Document{Value: line}, nil
+		},
+	}
+}
+
+// NewWeightedSourceReader creates a new instance of SourceReader for a WeightedPlainFormat source, where each
+// line is a document value optionally followed by a tab and a non-negative numeric weight, i.e. "bmw x5\t1500"
+func NewWeightedSourceReader(reader io.Reader) SourceReader {
+	return &lineSourceReader{
+		reader: reader,
+		parse:  parseWeightedLine,
+	}
+}
+
+// NewJSONLinesSourceReader creates a new instance of SourceReader for a JSONLinesFormat source,
+// where each line is a JSON object. The string field of the object is indexed, the optional
+// numeric weightField is the document weight and the rest fields are stored as the document payload.
+// The values of the attributes fields are also kept as the document attributes
+func NewJSONLinesSourceReader(reader io.Reader, field, weightField string, attributes []string) SourceReader {
+	return &lineSourceReader{
+		reader: reader,
+		parse: func(line string) (Document, error) {
+			return parseJSONLine(line, field, weightField, attributes)
+		},
+	}
+}
+
+// OpenSource opens the source of the given description and returns a reader
+// and a closer for it
+func OpenSource(description IndexDescription) (SourceReader, io.Closer, error) {
+	file, err := os.Open(description.GetSourcePath())
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open a source file: %w", err)
+	}
+
+	switch description.Format {
+	case "", PlainFormat:
+		return NewSourceReader(file), file, nil
+	case WeightedPlainFormat:
+		return NewWeightedSourceReader(file), file, nil
+	case JSONLinesFormat:
+		if description.Field == "" {
+			_ = file.Close()
+			return nil, nil, fmt.Errorf("field to index should be set for %s source", description.Format)
+		}
+
+		return NewJSONLinesSourceReader(
+			file,
+			description.Field,
+			description.WeightField,
+			description.Attributes,
+		), file, nil
+	default:
+		_ = file.Close()
+		return nil, nil, fmt.Errorf("source format %s is not supported", description.Format)
+	}
+}
+
+// ReadSource reads all documents from the source of the given description
+func ReadSource(description IndexDescription) ([]Document, error) {
+	reader, closer, err := OpenSource(description)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer closer.Close()
+
+	docs := make([]Document, 0)
+
+	err = reader.Iterate(func(doc Document) error {
+		docs = append(docs, doc)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return docs, nil
+}
+
+// lineSourceReader implements SourceReader for a source, where each line is a document
+type lineSourceReader struct {
+	reader io.Reader
+	parse  func(line string) (Document, error)
+}
+
+// Iterate calls fn on each document of the source
+func (r *lineSourceReader) Iterate(fn func(doc Document) error) error {
+	scanner := bufio.NewScanner(r.reader)
+
+	for key := dictionary.Key(0); scanner.Scan(); key++ {
+		doc, err := r.parse(scanner.Text())
+
+		if err != nil {
+			return fmt.Errorf("failed to parse line %d: %w", key+1, err)
+		}
+
+		doc.Key = key
+
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// parseWeightedLine splits the given source line into the document value and its weight,
+// which is the last tab separated field of the line. A line without tabs is a value without a weight
+func parseWeightedLine(line string) (Document, error) {
+	i := strings.LastIndexByte(line, '\t')
+
+	if i < 0 {
+		return Document{Value: line}, nil
+	}
+
+	weight, err := strconv.ParseFloat(line[i+1:], 64)
+
+	if err != nil {
+		return Document{}, fmt.Errorf("weight should be a number: %w", err)
+	}
+
+	if err := validateWeight(weight); err != nil {
+		return Document{}, err
+	}
+
+	return Document{Value: line[:i], Weight: weight}, nil
+}
+
+// parseJSONLine retrieves the document value, its weight, its attributes and its payload from the given JSON object
+func parseJSONLine(line, field, weightField string, attributes []string) (Document, error) {
+	fields := map[string]json.RawMessage{}
+
+	if err := json.Unmarshal([]byte(line), &fields); err != nil {
+		return Document{}, fmt.Errorf("failed to decode a document: %w", err)
+	}
+
+	doc := Document{}
+	raw, ok := fields[field]
+
+	if !ok {
+		return Document{}, fmt.Errorf("document has no field %s", field)
+	}
+
+	if err := json.Unmarshal(raw, &doc.Value); err != nil {
+		return Document{}, fmt.Errorf("field %s should be a string: %w", field, err)
+	}
+
+	delete(fields, field)
+
+	for _, attribute := range attributes {
+		raw, ok := fields[attribute]
+
+		if !ok {
+			continue
+		}
+
+		values, err := parseAttribute(raw)
+
+		if err != nil {
+			return Document{}, fmt.Errorf("field %s should be a string, a number or a list of them: %w", attribute, err)
+		}
+
+		if doc.Attributes == nil {
+			doc.Attributes = make(map[string][]string)
+		}
+
+		doc.Attributes[attribute] = values
+	}
+
+	if raw, ok := fields[weightField]; ok && weightField != "" {
+		if err := json.Unmarshal(raw, &doc.Weight); err != nil {
+			return Document{}, fmt.Errorf("field %s should be a number: %w", weightField, err)
+		}
+
+		if err := validateWeight(doc.Weight); err != nil {
+			return Document{}, err
+		}
+	}
+
+	if len(fields) > 0 {
+		payload, err := json.Marshal(fields)
+
+		if err != nil {
+			return Document{}, fmt.Errorf("failed to encode a document payload: %w", err)
+		}
+
+		doc.Payload = payload
+	}
+
+	return doc, nil
+}
+
+// parseAttribute returns the values of the given attribute field
+func parseAttribute(raw json.RawMessage) ([]string, error) {
+	var value interface{}
+
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+
+	list, ok := value.([]interface{})
+
+	if !ok {
+		list = []interface{}{value}
+	}
+
+	values := make([]string, 0, len(list))
+
+	for _, item := range list {
+		switch v := item.(type) {
+		case string:
+			values = append(values, v)
+		case float64:
+			values = append(values, strconv.FormatFloat(v, 'f', -1, 64))
+		case nil:
+			continue
+		default:
+			return nil, fmt.Errorf("unexpected value %v", v)
+		}
+	}
+
+	return values, nil
+}
+
+// validateWeight checks that the given document weight is valid
+func validateWeight(weight float64) error {
+	if weight < 0 || math.IsNaN(weight) || math.IsInf(weight, 0) {
+		return fmt.Errorf("weight should be a non-negative number, got %v", weight)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package suggest
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceReader(t *testing.T) {
+	source := "mazda 6\t2015\nhouse\tof cards\nx\t-1\n"
+	expected := []Document{
+		{Key: 0, Value: "mazda 6\t2015"},
+		{Key: 1, Value: "house\tof cards"},
+		{Key: 2, Value: "x\t-1"},
+	}
+
+	actual := []Document{}
+
+	err := NewSourceReader(strings.NewReader(source)).Iterate(func(doc Document) error {
+		actual = append(actual, doc)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestWeightedSourceReader(t *testing.T) {
+	source := "bmw x5\t1500\nbmw x5 m50d\nlada\t0.5\n"
+	expected := []Document{
+		{Key: 0, Value: "bmw x5", Weight: 1500},
+		{Key: 1, Value: "bmw x5 m50d"},
+		{Key: 2, Value: "lada", Weight: 0.5},
+	}
+
+	actual := []Document{}
+
+	err := NewWeightedSourceReader(strings.NewReader(source)).Iterate(func(doc Document) error {
+		actual = append(actual, doc)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	for _, line := range []string{"bmw\t-1", "bmw\tNaN", "bmw\t+Inf", "house\tof cards"} {
+		err = NewWeightedSourceReader(strings.NewReader(line)).Iterate(func(doc Document) error {
+			return nil
+		})
+
+		assert.Error(t, err, line)
+	}
+}
+
+func TestJSONLinesSource(t *testing.T) {
+	source := `{"title": "BMW X5", "id": 5, "url": "/bmw/x5", "popularity": 10}
+{"title": "BMW X6", "id": 6}
+{"title": "AUDI Q5"}
+`
+	_, description := buildTestIndex(t, source, func(description *IndexDescription) {
+		description.Format = JSONLinesFormat
+		description.Field = "title"
+		description.WeightField = "popularity"
+	})
+
+	for _, driver := range []Driver{DiscDriver, RAMDriver} {
+		description.Driver = driver
+		service := NewService()
+		assert.NoError(t, service.AddIndexByDescription(description))
+
+		result, err := service.Autocomplete(context.Background(), description.Name, "BMW", 5, WithAutocompleteRanking(WeightRanking))
+		assert.NoError(t, err)
+		assert.Equal(t, []ResultItem{
+			{Score: 1, Value: "BMW X5", Payload: []byte(`{"id":5,"popularity":10,"url":"/bmw/x5"}`), Highlights: []Highlight{{0, 3}}},
+			{Score: 0, Value: "BMW X6", Payload: []byte(`{"id":6}`), Highlights: []Highlight{{0, 3}}},
+		}, result)
+
+		result, err = service.Autocomplete(context.Background(), description.Name, "AUDI", 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []ResultItem{{Value: "AUDI Q5", Highlights: []Highlight{{0, 4}}}}, result)
+	}
+
+	service := NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+	assert.NoError(t, service.UpdateDocuments(description.Name, []Document{
+		{Key: 2, Value: "AUDI Q7", Payload: []byte(`{"id":7}`)},
+	}))
+
+	result, err := service.Autocomplete(context.Background(), description.Name, "AUDI", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []ResultItem{{Value: "AUDI Q7", Payload: []byte(`{"id":7}`), Highlights: []Highlight{{0, 4}}}}, result)
+}
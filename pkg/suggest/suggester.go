@@ -1,6 +1,7 @@
 package suggest
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -15,8 +16,10 @@ import (
 // Suggester is the interface that provides the access to
 // approximate string search
 type Suggester interface {
-	// Suggest returns top-k similar candidates
-	Suggest(query string, similarity float64, metric metric.Metric, factory CollectorManagerFactory) ([]Candidate, error)
+	// Suggest returns top-k similar candidates.
+	// If the context is done before the search is finished, the candidates collected so far
+	// are returned along with the context error
+	Suggest(ctx context.Context, query string, similarity float64, metric metric.Metric, factory CollectorManagerFactory) ([]Candidate, error)
 }
 
 // maxSearchQueriesAtOnce tells how many goroutines can be used at once for a search query
@@ -43,7 +46,13 @@ func NewSuggester(
 }
 
 // Suggest returns top-k similar candidates
-func (n *nGramSuggester) Suggest(query string, similarity float64, metric metric.Metric, factory CollectorManagerFactory) ([]Candidate, error) {
+func (n *nGramSuggester) Suggest(
+	ctx context.Context,
+	query string,
+	similarity float64,
+	metric metric.Metric,
+	factory CollectorManagerFactory,
+) ([]Candidate, error) {
 	tokens := n.tokenizer.Tokenize(query)
 
 	if len(tokens) == 0 {
@@ -70,6 +79,11 @@ func (n *nGramSuggester) Suggest(query string, similarity float64, metric metric
 	for i := 0; i < utils.Min(maxSearchQueriesAtOnce, bMax-bMin+1); i++ {
 		workerPool.Go(func() error {
 			for sizeB := range sizeCh {
+				// the search has been interrupted, so the rest sizes are skipped
+				if ctx.Err() != nil {
+					continue
+				}
+
 				threshold := metric.Threshold(similarityHolder.Load(), sizeA, sizeB)
 
 				// it means that the similarity has been changed and we will skip this value processing
@@ -86,7 +100,8 @@ func (n *nGramSuggester) Suggest(query string, similarity float64, metric metric
 				collector := collectorManager.Create()
 				collector.SetScorer(NewMetricScorer(metric, sizeA, sizeB))
 
-				if err := n.searcher.Search(invertedIndex, tokens, threshold, collector); err != nil {
+				// the candidates found before an interruption are still collected
+				if err := n.searcher.Search(ctx, invertedIndex, tokens, threshold, collector); err != nil && ctx.Err() == nil {
 					return fmt.Errorf("failed to search posting lists: %w", err)
 				}
 
@@ -98,8 +113,8 @@ func (n *nGramSuggester) Suggest(query string, similarity float64, metric metric
 					return err
 				}
 
-				if fuzzy, ok := collectorManager.(*FuzzyCollectorManager); ok && fuzzy.GetLowestScore() > similarityHolder.Load() {
-					similarityHolder.Store(fuzzy.GetLowestScore())
+				if lowest, ok := collectorManager.(lowestScoreCollectorManager); ok && lowest.GetLowestScore() > similarityHolder.Load() {
+					similarityHolder.Store(lowest.GetLowestScore())
 				}
 
 				locker.Unlock()
@@ -127,5 +142,5 @@ func (n *nGramSuggester) Suggest(query string, similarity float64, metric metric
 		return nil, err
 	}
 
-	return collectorManager.GetCandidates(), nil
+	return collectorManager.GetCandidates(), ctx.Err()
 }
@@ -1,34 +1,102 @@
 package suggest
 
 import (
+	"fmt"
+
 	"github.com/suggest-go/suggest/pkg/alphabet"
 	"github.com/suggest-go/suggest/pkg/analysis"
 )
 
 // NewSuggestTokenizer creates a tokenizer for suggester service
-func NewSuggestTokenizer(d IndexDescription) analysis.Tokenizer {
+func NewSuggestTokenizer(d IndexDescription) (analysis.Tokenizer, error) {
 	filter := analysis.NewNormalizerFilter(alphabet.CreateAlphabet(d.Alphabet), d.Pad)
 
-	return analysis.NewWrapTokenizer(
-		analysis.NewFilterTokenizer(
-			analysis.NewNGramTokenizer(d.NGramSize),
-			filter,
+	return analyze(
+		analysis.NewWrapTokenizer(
+			analysis.NewFilterTokenizer(
+				analysis.NewNGramTokenizer(d.NGramSize),
+				filter,
+			),
+			d.Wrap[0],
+			d.Wrap[1],
 		),
-		d.Wrap[0],
-		d.Wrap[1],
+		d,
 	)
 }
 
 // NewAutocompleteTokenizer creates a tokenizer for autocomplete service
-func NewAutocompleteTokenizer(d IndexDescription) analysis.Tokenizer {
+func NewAutocompleteTokenizer(d IndexDescription) (analysis.Tokenizer, error) {
 	filter := analysis.NewNormalizerFilter(alphabet.CreateAlphabet(d.Alphabet), d.Pad)
 
-	return analysis.NewWrapTokenizer(
-		analysis.NewFilterTokenizer(
-			analysis.NewNGramTokenizer(d.NGramSize),
-			filter,
+	return analyze(
+		analysis.NewWrapTokenizer(
+			analysis.NewFilterTokenizer(
+				analysis.NewNGramTokenizer(d.NGramSize),
+				filter,
+			),
+			d.Wrap[0],
+			"", // do not add a wrap symbol to the tail of query
+		),
+		d,
+	)
+}
+
+// NewInfixAutocompleteTokenizer creates a tokenizer for autocomplete service, that matches the query
+// in the middle of a candidate. InfixMatching tokenizes the query as is, so it matches at any position.
+// WordBoundaryMatching prepends the pad symbol, that replaces the word separators of the indexed documents,
+// so the query matches at the beginning of any word
+func NewInfixAutocompleteTokenizer(d IndexDescription, matching AutocompleteMatching) (analysis.Tokenizer, error) {
+	filter := analysis.NewNormalizerFilter(alphabet.CreateAlphabet(d.Alphabet), d.Pad)
+	start := ""
+
+	if matching == WordBoundaryMatching {
+		start = d.Pad
+	}
+
+	return analyze(
+		analysis.NewWrapTokenizer(
+			analysis.NewFilterTokenizer(
+				analysis.NewNGramTokenizer(d.NGramSize),
+				filter,
+			),
+			start,
+			"",
+		),
+		d,
+	)
+}
+
+// newTextTokenizer returns a tokenizer, that brings a text to the same form as the n-gram tokenizers do,
+// i.e. lowercases, normalizes and wraps it with start and end, and then splits it with the given tokenizer
+func newTextTokenizer(d IndexDescription, tokenizer analysis.Tokenizer, start, end string) (analysis.Tokenizer, error) {
+	stages := []analysis.Stage{
+		analysis.FilterStage(analysis.NewLowercaseFilter()),
+		analysis.FilterStage(analysis.NewNormalizerFilter(alphabet.CreateAlphabet(d.Alphabet), d.Pad)),
+	}
+
+	return analyze(
+		analysis.NewWrapTokenizer(
+			analysis.NewPipelineTokenizer(stages, tokenizer),
+			start,
+			end,
 		),
-		d.Wrap[0],
-		"", // do not add a wrap symbol to the tail of query
+		d,
 	)
 }
+
+// analyze makes the n-gram tokenizer split the tokens of the analysis pipeline of the description
+// instead of the raw text. The same pipeline is used for indexing and querying, so documents
+// and queries share the analyzed n-grams
+func analyze(tokenizer analysis.Tokenizer, d IndexDescription) (analysis.Tokenizer, error) {
+	stages, err := d.analysisStages()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create analysis pipeline: %w", err)
+	}
+
+	if len(stages) == 0 {
+		return tokenizer, nil
+	}
+
+	return analysis.NewPipelineTokenizer(stages, tokenizer), nil
+}
@@ -0,0 +1,103 @@
+package suggest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestTextTokenizer(t *testing.T) {
+	description := IndexDescription{
+		Alphabet:        []string{"english"},
+		Pad:             "$",
+		Transliteration: "informal",
+	}
+
+	tokenizer, err := newTextTokenizer(description, textTokenizer{}, "$", "$")
+	assert.NoError(t, err)
+
+	// the text is analyzed before the n-gram tokenizers would split it
+	assert.Equal(t, []string{"$mersedes$bents$"}, tokenizer.Tokenize("Мерседес-Бенц"))
+
+	description.Normalization = &analysis.UnicodeNormalization{StripDiacritics: true}
+	tokenizer, err = newTextTokenizer(description, textTokenizer{}, "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"skoda$citroen"}, tokenizer.Tokenize("Škoda Citroën"))
+}
+
+func TestTransliteration(t *testing.T) {
+	service, description := newTestService(t, "Mercedes Benz\nМерседес Бенц\nЖигули\n", func(description *IndexDescription) {
+		description.Transliteration = "informal"
+	})
+
+	result, err := service.Autocomplete(context.Background(), description.Name, "zhig", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Жигули"}, resultValues(result))
+
+	result, err = service.Autocomplete(context.Background(), description.Name, "мер", 5)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Mercedes Benz", "Мерседес Бенц"}, resultValues(result))
+
+	searchConf, err := NewSearchConfig("mersedes", 5, metric.CosineMetric(), 0.4)
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, "Мерседес Бенц", result[0].Value)
+}
+
+func TestInvalidAnalysisTokenizer(t *testing.T) {
+	descriptions, err := ReadConfigs("testdata/config.json")
+	assert.NoError(t, err)
+
+	description := descriptions[0]
+	description.Transliteration = "unknown"
+
+	_, err = NewSuggestTokenizer(description)
+	assert.Error(t, err)
+
+	_, err = NewAutocompleteTokenizer(description)
+	assert.Error(t, err)
+
+	_, err = NewInfixAutocompleteTokenizer(description, InfixMatching)
+	assert.Error(t, err)
+}
+
+func TestUnicodeNormalization(t *testing.T) {
+	service, description := newTestService(t, "Škoda Octavia\nCitroën C4\nЁлка\n", func(description *IndexDescription) {
+		description.Normalization = &analysis.UnicodeNormalization{
+			CaseFolding:     true,
+			StripDiacritics: true,
+			Mappings:        map[string]string{"ё": "е", "й": "й"},
+		}
+	})
+
+	for query, expected := range map[string]string{"skod": "Škoda Octavia", "citroen": "Citroën C4", "елк": "Ёлка"} {
+		result, err := service.Autocomplete(context.Background(), description.Name, query, 5)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{expected}, resultValues(result))
+	}
+}
+
+func TestAnalysisPipeline(t *testing.T) {
+	service, description := newTestService(t, "Running Shoes\nRun Shop\nShoe Box\n", func(description *IndexDescription) {
+		assert.NoError(t, json.Unmarshal([]byte(`[{"type": "word"}, {"type": "stemmer", "lang": "en"}]`), &description.Analysis))
+	})
+
+	searchConf, err := NewSearchConfig("the run shoe", 5, metric.CosineMetric(), 0.7)
+	assert.NoError(t, err)
+
+	result, err := service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, "Running Shoes", result[0].Value)
+	assert.Equal(t, 1.0, result[0].Score)
+
+	broken := description
+	broken.Name = "broken"
+	assert.NoError(t, json.Unmarshal([]byte(`[{"type": "stemmer", "lang": "xx"}]`), &broken.Analysis))
+	assert.Error(t, service.AddRunTimeIndex(broken))
+}
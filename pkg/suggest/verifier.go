@@ -0,0 +1,96 @@
+package suggest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+)
+
+// candidateVerifier checks the candidates, that the n-gram search can't tell for sure, against their values.
+// The infix autocomplete can find the query n-grams at different positions of a candidate, and the typo-tolerant
+// autocomplete finds the candidates sharing only some of them. The query and the candidate are compared in the
+// analyzed form, i.e. after the same analysis, normalization and wrapping the n-grams are made of
+type candidateVerifier struct {
+	pad     string
+	text    analysis.Tokenizer
+	values  analysis.Tokenizer
+	queries map[AutocompleteMatching]analysis.Tokenizer
+}
+
+// newCandidateVerifier creates a new instance of candidateVerifier for the index of the given description
+func newCandidateVerifier(d IndexDescription) (*candidateVerifier, error) {
+	text, err := newTextTokenizer(d, textTokenizer{}, "", "")
+
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := newTextTokenizer(d, textTokenizer{}, d.Wrap[0], d.Wrap[1])
+
+	if err != nil {
+		return nil, err
+	}
+
+	verifier := &candidateVerifier{
+		pad:     d.Pad,
+		text:    text,
+		values:  values,
+		queries: make(map[AutocompleteMatching]analysis.Tokenizer, 2),
+	}
+
+	// the same as the infix autocomplete tokenizers do, the word boundary query starts with the pad symbol
+	for matching, start := range map[AutocompleteMatching]string{InfixMatching: "", WordBoundaryMatching: d.Pad} {
+		if verifier.queries[matching], err = newTextTokenizer(d, textTokenizer{}, start, ""); err != nil {
+			return nil, err
+		}
+	}
+
+	return verifier, nil
+}
+
+// analyze returns the analyzed form of the given text, the analyzed tokens are joined with the pad symbol
+func (v *candidateVerifier) analyze(text string) string {
+	return strings.Join(v.text.Tokenize(text), v.pad)
+}
+
+// verifyInfix returns a function, that tells whether the value of the given document of the dictionary
+// contains each analyzed token of the query with the given matching
+func (v *candidateVerifier) verifyInfix(
+	dict dictionary.Dictionary,
+	query string,
+	matching AutocompleteMatching,
+) func(key index.Position) (bool, error) {
+	tokens := v.queries[matching].Tokenize(query)
+
+	return func(key index.Position) (bool, error) {
+		value, err := dict.Get(key)
+
+		if err != nil {
+			return false, fmt.Errorf("failed to get a candidate value: %w", err)
+		}
+
+		values := v.values.Tokenize(value)
+
+		for _, token := range tokens {
+			if !containsToken(values, token) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	}
+}
+
+// containsToken tells whether the given token is a substring of any of the values
+func containsToken(values []analysis.Token, token analysis.Token) bool {
+	for _, value := range values {
+		if strings.Contains(value, token) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,69 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+)
+
+func TestCandidateVerifier(t *testing.T) {
+	verifier, err := newCandidateVerifier(IndexDescription{
+		Alphabet:        []string{"english", "numbers"},
+		Pad:             "$",
+		Wrap:            [2]string{"$", "$"},
+		Transliteration: "informal",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "mersedes$bents", verifier.analyze("Мерседес-Бенц"))
+
+	dict := dictionary.NewInMemoryDictionary([]string{"Golf Club", "Volkswagen Golf", "Minigolf", "Olfgol", "Гольф"})
+	testCases := []struct {
+		matching AutocompleteMatching
+		query    string
+		expected []index.Position
+	}{
+		{WordBoundaryMatching, "golf", []index.Position{0, 1, 4}},
+		{InfixMatching, "golf", []index.Position{0, 1, 2, 4}},
+		{InfixMatching, "golf cl", []index.Position{0}},
+	}
+
+	for _, testCase := range testCases {
+		verify := verifier.verifyInfix(dict, testCase.query, testCase.matching)
+		verified := []index.Position{}
+
+		for key := index.Position(0); key < 5; key++ {
+			ok, err := verify(key)
+			assert.NoError(t, err)
+
+			if ok {
+				verified = append(verified, key)
+			}
+		}
+
+		assert.Equal(t, testCase.expected, verified, testCase.query)
+	}
+}
+
+func TestInfixAutocompleteVerification(t *testing.T) {
+	// "Olfgol" shares all the n-grams of "golf", but doesn't contain it
+	service, description := newTestService(t, "Golf Club\nVolkswagen Golf\nMinigolf\nOlfgol\n", nil)
+
+	testCases := []struct {
+		matching AutocompleteMatching
+		expected []string
+	}{
+		{WordBoundaryMatching, []string{"Golf Club", "Volkswagen Golf"}},
+		{InfixMatching, []string{"Golf Club", "Volkswagen Golf", "Minigolf"}},
+	}
+
+	for _, testCase := range testCases {
+		opts := []QueryOption{WithAutocompleteMatching(testCase.matching), WithAutocompleteRanking(LengthRanking)}
+		result, err := service.Autocomplete(context.Background(), description.Name, "golf", 5, opts...)
+		assert.NoError(t, err)
+		assert.ElementsMatch(t, testCase.expected, resultValues(result), testCase.matching)
+	}
+}
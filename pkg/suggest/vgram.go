@@ -0,0 +1,120 @@
+package suggest
+
+import (
+	"fmt"
+
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/store"
+	"github.com/suggest-go/suggest/pkg/vgram"
+)
+
+// BuildIndexTokenizer returns a tokenizer for indexing the dictionary with the tokenizer of the description.
+// The gram dictionary of VGramTokenizer is built from the dictionary and is persisted in the directory,
+// so it can be opened by OpenIndexTokenizer
+func BuildIndexTokenizer(directory store.Directory, dict dictionary.Dictionary, description IndexDescription) (analysis.Tokenizer, error) {
+	if description.Tokenizer != VGramTokenizer {
+		return description.GetIndexTokenizer()
+	}
+
+	qMin, qMax, threshold := description.vgramBounds()
+	builder, err := vgram.NewVGramDictionaryBuilder(uint32(qMin), uint32(qMax), uint32(threshold))
+
+	if err != nil {
+		return nil, err
+	}
+
+	texts, err := newVGramTextTokenizer(description, textTokenizer{})
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = dict.Iterate(func(key dictionary.Key, value dictionary.Value) error {
+		for _, text := range texts.Tokenize(value) {
+			builder.Add(text)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to count grams: %w", err)
+	}
+
+	grams := builder.Build()
+
+	if err := writeVGramDictionary(directory, vgramFileName(description.Name), grams); err != nil {
+		return nil, err
+	}
+
+	return NewVGramSuggestTokenizer(description, grams)
+}
+
+// OpenIndexTokenizer returns a tokenizer of the already indexed data with the tokenizer of the description
+func OpenIndexTokenizer(directory store.Directory, description IndexDescription) (analysis.Tokenizer, error) {
+	if description.Tokenizer != VGramTokenizer {
+		return description.GetIndexTokenizer()
+	}
+
+	input, err := directory.OpenInput(vgramFileName(description.Name))
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vgram dictionary: %w", err)
+	}
+
+	defer input.Close()
+
+	grams := &vgram.VGramDictionary{}
+
+	if _, err := grams.Load(input); err != nil {
+		return nil, fmt.Errorf("failed to read vgram dictionary: %w", err)
+	}
+
+	return NewVGramSuggestTokenizer(description, grams)
+}
+
+// NewVGramSuggestTokenizer creates a tokenizer for suggester service, that decomposes
+// a text into the variable length grams of the given dictionary
+func NewVGramSuggestTokenizer(d IndexDescription, grams *vgram.VGramDictionary) (analysis.Tokenizer, error) {
+	return newVGramTextTokenizer(d, vgram.NewTokenizer(grams))
+}
+
+// newVGramTextTokenizer returns a tokenizer, that brings a text to the same form as NewSuggestTokenizer does,
+// i.e. lowercases, normalizes and wraps it, and then splits it with the given tokenizer
+func newVGramTextTokenizer(d IndexDescription, tokenizer analysis.Tokenizer) (analysis.Tokenizer, error) {
+	return newTextTokenizer(d, tokenizer, d.Wrap[0], d.Wrap[1])
+}
+
+// textTokenizer keeps a text as a single token
+type textTokenizer struct{}
+
+// Tokenize splits the given text on a sequence of tokens
+func (textTokenizer) Tokenize(text string) []analysis.Token {
+	return []analysis.Token{text}
+}
+
+// writeVGramDictionary writes the gram dictionary to the file with the given name
+func writeVGramDictionary(directory store.Directory, fileName string, grams *vgram.VGramDictionary) error {
+	output, err := directory.CreateOutput(fileName)
+
+	if err != nil {
+		return fmt.Errorf("failed to create vgram dictionary file: %w", err)
+	}
+
+	if _, err := grams.Store(output); err != nil {
+		_ = output.Close()
+		return fmt.Errorf("failed to write vgram dictionary: %w", err)
+	}
+
+	if err := output.Close(); err != nil {
+		return fmt.Errorf("failed to close vgram dictionary file: %w", err)
+	}
+
+	return nil
+}
+
+// vgramFileName returns the name of the gram dictionary file of the given index
+func vgramFileName(name string) string {
+	return fmt.Sprintf("%s.vg", name)
+}
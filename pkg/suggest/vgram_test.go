@@ -0,0 +1,54 @@
+package suggest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestVGramTokenizer(t *testing.T) {
+	source := "Samsung Galaxy S21 Ultra\nSamsung Galaxy S21\nSamsung Galaxy A52\nSamsung Galaxy Tab S7\nApple iPhone 12 Pro\nApple iPhone 12 Mini\n"
+	directory, description := buildTestIndex(t, source, func(description *IndexDescription) {
+		description.Tokenizer = VGramTokenizer
+		description.VGram = VGramDescription{QMax: 6, Threshold: 2}
+	})
+
+	tokenizer, err := OpenIndexTokenizer(directory, description)
+	assert.NoError(t, err)
+
+	// the frequent trigrams are extended to the longer grams
+	assert.Contains(t, tokenizer.Tokenize("Samsung Galaxy"), "$samsu")
+
+	suggest := func(service *Service, query string) []string {
+		searchConf, err := NewSearchConfig(query, 2, metric.CosineMetric(), 0.5)
+		assert.NoError(t, err)
+
+		result, err := service.Suggest(context.Background(), description.Name, searchConf)
+		assert.NoError(t, err)
+
+		return resultValues(result)
+	}
+
+	for _, driver := range []Driver{DiscDriver, RAMDriver} {
+		description.Driver = driver
+		service := NewService()
+		assert.NoError(t, service.AddIndexByDescription(description))
+
+		assert.Equal(t, []string{"Samsung Galaxy S21", "Samsung Galaxy S21 Ultra"}, suggest(service, "samsung galaxy s21"))
+		assert.Equal(t, []string{"Apple iPhone 12 Mini", "Apple iPhone 12 Pro"}, suggest(service, "iphone 12 mini"))
+
+		_, err = service.Autocomplete(context.Background(), description.Name, "Samsung", 5)
+		assert.Error(t, err)
+	}
+
+	description.Driver = DiscDriver
+	service := NewService()
+	assert.NoError(t, service.AddOnDiscIndex(description))
+	assert.NoError(t, service.UpdateDocuments(description.Name, []Document{{Key: 100, Value: "Samsung Galaxy S22"}}))
+	assert.Equal(t, []string{"Samsung Galaxy S22"}, suggest(service, "samsung galaxy s22")[:1])
+
+	description.Tokenizer = "bigram"
+	assert.Error(t, NewService().AddRunTimeIndex(description))
+}
@@ -0,0 +1,178 @@
+package suggest
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/index"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+// Weights provides access to the popularity weights of the dictionary documents
+type Weights interface {
+	// Get returns the weight of the document with the given key, 0 if the document has no weight
+	Get(key dictionary.Key) float64
+	// Max returns the biggest weight of the documents
+	Max() float64
+}
+
+// NewWeights creates a new instance of Weights for the given document weights
+func NewWeights(weights map[dictionary.Key]float64) Weights {
+	w := &sortedWeights{
+		keys:   make([]dictionary.Key, 0, len(weights)),
+		values: make([]float32, 0, len(weights)),
+	}
+
+	for key := range weights {
+		w.keys = append(w.keys, key)
+	}
+
+	sort.Slice(w.keys, func(i, j int) bool {
+		return w.keys[i] < w.keys[j]
+	})
+
+	for _, key := range w.keys {
+		value := float32(weights[key])
+		w.values = append(w.values, value)
+
+		if float64(value) > w.max {
+			w.max = float64(value)
+		}
+	}
+
+	return w
+}
+
+// sortedWeights implements Weights with the sorted list of the weighted documents,
+// the documents without a weight are not stored
+type sortedWeights struct {
+	keys   []dictionary.Key
+	values []float32
+	max    float64
+}
+
+// Get returns the weight of the document with the given key, 0 if the document has no weight
+func (w *sortedWeights) Get(key dictionary.Key) float64 {
+	i := sort.Search(len(w.keys), func(i int) bool {
+		return w.keys[i] >= key
+	})
+
+	if i < len(w.keys) && w.keys[i] == key {
+		return float64(w.values[i])
+	}
+
+	return 0
+}
+
+// Max returns the biggest weight of the documents
+func (w *sortedWeights) Max() float64 {
+	return w.max
+}
+
+// OpenWeights reads the document weights of all segments of the on-disc index with the given description
+func OpenWeights(directory store.Directory, description IndexDescription) (Weights, error) {
+	infos, err := index.ReadSegmentInfos(directory, description.Name)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read segments: %w", err)
+	}
+
+	weights := make(map[dictionary.Key]float64)
+
+	for _, info := range infos.Segments {
+		deletions, err := index.ReadDeletions(directory, info)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = readWeights(directory, weightsFileName(info.Name), func(key dictionary.Key, weight float64) {
+			if !deletions.Contains(key) {
+				weights[key] = weight
+			}
+		})
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read weights of segment %s: %w", info.Name, err)
+		}
+	}
+
+	return NewWeights(weights), nil
+}
+
+// readWeights reads the weights file with the given name and calls fn on each weighted document.
+// A missing file means that the documents have no weights
+func readWeights(directory store.Directory, fileName string, fn func(key dictionary.Key, weight float64)) error {
+	if !directory.Exists(fileName) {
+		return nil
+	}
+
+	input, err := directory.OpenInput(fileName)
+
+	if err != nil {
+		return fmt.Errorf("failed to open weights file: %w", err)
+	}
+
+	size, err := input.ReadUInt32()
+
+	if err != nil {
+		return fmt.Errorf("failed to read the number of weights: %w", err)
+	}
+
+	for i := uint32(0); i < size; i++ {
+		key, err := input.ReadUInt32()
+
+		if err != nil {
+			return fmt.Errorf("failed to read a document key: %w", err)
+		}
+
+		weight, err := input.ReadUInt32()
+
+		if err != nil {
+			return fmt.Errorf("failed to read a document weight: %w", err)
+		}
+
+		fn(key, float64(math.Float32frombits(weight)))
+	}
+
+	return input.Close()
+}
+
+// writeWeights persists the given document weights into the file with the given name.
+// The file starts with the number of the weighted documents followed by the sorted (key, weight) pairs
+func writeWeights(directory store.Directory, fileName string, weights map[dictionary.Key]float64) error {
+	output, err := directory.CreateOutput(fileName)
+
+	if err != nil {
+		return fmt.Errorf("failed to create weights file: %w", err)
+	}
+
+	w := NewWeights(weights).(*sortedWeights)
+
+	if _, err := output.WriteUInt32(uint32(len(w.keys))); err != nil {
+		return fmt.Errorf("failed to write the number of weights: %w", err)
+	}
+
+	for i, key := range w.keys {
+		if _, err := output.WriteUInt32(key); err != nil {
+			return fmt.Errorf("failed to write a document key: %w", err)
+		}
+
+		if _, err := output.WriteUInt32(math.Float32bits(w.values[i])); err != nil {
+			return fmt.Errorf("failed to write a document weight: %w", err)
+		}
+	}
+
+	if err := output.Close(); err != nil {
+		return fmt.Errorf("failed to close weights file: %w", err)
+	}
+
+	return nil
+}
+
+// weightsFileName returns the name of the weights file of the given index segment
+func weightsFileName(segment string) string {
+	return fmt.Sprintf("%s.wt", segment)
+}
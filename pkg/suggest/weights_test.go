@@ -0,0 +1,83 @@
+package suggest
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/metric"
+	"github.com/suggest-go/suggest/pkg/store"
+)
+
+func TestWeights(t *testing.T) {
+	weights := NewWeights(map[dictionary.Key]float64{7: 2.5, 1: 100, 3: 0.5})
+
+	assert.Equal(t, 100.0, weights.Max())
+	assert.Equal(t, 2.5, weights.Get(7))
+	assert.Equal(t, 0.5, weights.Get(3))
+	assert.Equal(t, 0.0, weights.Get(2))
+	assert.Equal(t, 0.0, weights.Get(8))
+	assert.Equal(t, 0.0, NewWeights(nil).Max())
+}
+
+func TestPersistedWeights(t *testing.T) {
+	directory := store.NewRAMDirectory()
+	expected := map[dictionary.Key]float64{7: 2.5, 1: 100, 3: 0.5}
+
+	assert.NoError(t, writeWeights(directory, weightsFileName("cars"), expected))
+
+	actual := map[dictionary.Key]float64{}
+	assert.NoError(t, readWeights(directory, weightsFileName("cars"), func(key dictionary.Key, weight float64) {
+		actual[key] = weight
+	}))
+	assert.Equal(t, expected, actual)
+
+	// a segment without the weights file has no weighted documents
+	assert.NoError(t, readWeights(directory, weightsFileName("cars_1"), func(key dictionary.Key, weight float64) {
+		assert.Fail(t, "the segment has no weights")
+	}))
+}
+
+func TestWeightFormulas(t *testing.T) {
+	linear, err := GetWeightFormula("linear", 0.3)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.7*0.5+0.3*0.25, linear(0.5, 25, 100), 1e-9)
+	assert.InDelta(t, 0.7*0.5, linear(0.5, 0, 0), 1e-9)
+
+	logarithmic, err := GetWeightFormula("log", 0.5)
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.5*0.5+0.5*math.Log1p(9)/math.Log1p(99), logarithmic(0.5, 9, 99), 1e-9)
+	assert.InDelta(t, 1.0, logarithmic(1, 99, 99), 1e-9)
+
+	_, err = GetWeightFormula("linear", 1.5)
+	assert.Error(t, err)
+
+	_, err = GetWeightFormula("square", 0.5)
+	assert.Error(t, err)
+}
+
+func TestWeightedRanking(t *testing.T) {
+	service, description := newTestService(t, "BMW X5 A\nBMW X5 B\t100\nBMW X6\t5\n", func(description *IndexDescription) {
+		description.Format = WeightedPlainFormat
+	})
+
+	searchConf, err := NewSearchConfig("BMW X5", 2, metric.CosineMetric(), 0.5)
+	assert.NoError(t, err)
+
+	result, err := service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BMW X5 A", "BMW X5 B"}, resultValues(result))
+
+	searchConf, err = NewSearchConfig("BMW X5", 2, metric.CosineMetric(), 0.5, WithWeightFormula(LinearWeightFormula(0.3)))
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BMW X5 B", "BMW X5 A"}, resultValues(result))
+
+	result, err = service.Autocomplete(context.Background(), description.Name, "BMW", 2, WithWeightFormula(LogWeightFormula(0.5)))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BMW X5 B", "BMW X6"}, resultValues(result))
+}
@@ -0,0 +1,312 @@
+package suggest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/suggest-go/suggest/pkg/alphabet"
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+// maxWordExpansions is the number of the most similar vocabulary words, that are looked up for a query word
+const maxWordExpansions = 16
+
+// WordMatching tells how Suggest combines the scores of the separately matched query words
+type WordMatching string
+
+const (
+	// AllWordsMatching returns only the documents, that match each query word,
+	// the document score is the mean of its word scores
+	AllWordsMatching WordMatching = "all"
+	// AnyWordMatching returns the documents, that match at least one query word,
+	// the document score is the sum of its word scores divided by the number of query words
+	AnyWordMatching WordMatching = "any"
+)
+
+// ParseWordMatching returns the word matching with the given name
+func ParseWordMatching(name string) (WordMatching, error) {
+	switch matching := WordMatching(name); matching {
+	case AllWordsMatching, AnyWordMatching:
+		return matching, nil
+	default:
+		return "", fmt.Errorf("word matching %s is not supported", name)
+	}
+}
+
+// WithWordMatching makes Suggest match each query word separately against the document words,
+// so the word order doesn't matter, i.e. "x5 bmw" matches "BMW X5". The last query word is also
+// matched as a prefix of a document word. The index should be described with WordSearch enabled
+func WithWordMatching(matching WordMatching) QueryOption {
+	return func(options *queryOptions) {
+		options.wordMatching = matching
+	}
+}
+
+// wordIndex is an n-gram index of the words of the documents,
+// that keeps for each word the list of documents containing it
+type wordIndex struct {
+	splitter    analysis.Tokenizer
+	description IndexDescription
+	words       NGramIndex
+	// vocabulary is the list of the distinct words, positions maps a word to its position in the list
+	vocabulary []string
+	positions  map[string]int
+	documents  []*roaring.Bitmap
+}
+
+// newWordIndex splits the documents of the dictionary into words and builds a RAM n-gram index
+// of the distinct words with the given description
+func newWordIndex(dict dictionary.Dictionary, description IndexDescription) (*wordIndex, error) {
+	splitter, err := newWordSplitter(description)
+
+	if err != nil {
+		return nil, err
+	}
+
+	index := &wordIndex{
+		splitter:    splitter,
+		description: description,
+		vocabulary:  []string{},
+		positions:   map[string]int{},
+		documents:   []*roaring.Bitmap{},
+	}
+
+	err = dict.Iterate(func(key dictionary.Key, value dictionary.Value) error {
+		for _, word := range index.splitter.Tokenize(value) {
+			index.documents[index.position(word)].Add(key)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to split documents into words: %w", err)
+	}
+
+	if index.words, err = index.buildWords(); err != nil {
+		return nil, err
+	}
+
+	for _, list := range index.documents {
+		list.RunOptimize()
+	}
+
+	return index, nil
+}
+
+// update returns a new index with the given changes applied, the previous values of the changed documents
+// are looked up in prev. The new index shares the document lists of the unchanged words with the index,
+// so the index itself is left untouched and can still be used by the queries in flight
+func (w *wordIndex) update(prev dictionary.Dictionary, docs []Document, deletes []dictionary.Key) (*wordIndex, error) {
+	index := &wordIndex{
+		splitter:    w.splitter,
+		description: w.description,
+		words:       w.words,
+		vocabulary:  w.vocabulary[:len(w.vocabulary):len(w.vocabulary)],
+		positions:   make(map[string]int, len(w.positions)),
+		documents:   append([]*roaring.Bitmap(nil), w.documents...),
+	}
+
+	for word, position := range w.positions {
+		index.positions[word] = position
+	}
+
+	lists := newCopyOnWriteLists()
+
+	err := forEachChange(prev, docs, deletes, func(key dictionary.Key, old, value dictionary.Value) {
+		for _, word := range index.splitter.Tokenize(old) {
+			if position, ok := index.positions[word]; ok {
+				index.documents[position] = lists.get(index.documents[position])
+				index.documents[position].Remove(key)
+			}
+		}
+
+		for _, word := range index.splitter.Tokenize(value) {
+			position := index.position(word)
+			index.documents[position] = lists.get(index.documents[position])
+			index.documents[position].Add(key)
+		}
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to split changed documents into words: %w", err)
+	}
+
+	// the n-gram index of the words is rebuilt only if the vocabulary has been extended
+	if len(index.vocabulary) > len(w.vocabulary) {
+		if index.words, err = index.buildWords(); err != nil {
+			return nil, err
+		}
+	}
+
+	return index, nil
+}
+
+// position returns the position of the given word, the missing word is appended to the vocabulary
+func (w *wordIndex) position(word string) int {
+	position, ok := w.positions[word]
+
+	if !ok {
+		position = len(w.vocabulary)
+		w.positions[word] = position
+		w.vocabulary = append(w.vocabulary, word)
+		w.documents = append(w.documents, roaring.New())
+	}
+
+	return position
+}
+
+// buildWords builds a RAM n-gram index of the vocabulary
+func (w *wordIndex) buildWords() (NGramIndex, error) {
+	// the last query word is autocompleted, so the words are always split into n-grams.
+	// The words have been analyzed by the splitter, so they are not analyzed again
+	description := w.description
+	description.Tokenizer = NGramTokenizer
+	description.Normalization = nil
+	description.Transliteration = ""
+	description.Analysis = nil
+	builder, err := NewRAMBuilder(dictionary.NewInMemoryDictionary(w.vocabulary), description)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to index words: %w", err)
+	}
+
+	index, err := builder.Build()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build words NGramIndex: %w", err)
+	}
+
+	return index, nil
+}
+
+// newWordSplitter returns a tokenizer, that passes a text through the analysis pipeline of the description
+// and splits the result into the words of the description alphabet, so the words are compared in the same
+// analyzed form as the n-grams are. The text is just lowercased if the description has no analysis
+func newWordSplitter(description IndexDescription) (analysis.Tokenizer, error) {
+	stages, err := description.analysisStages()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to create analysis pipeline: %w", err)
+	}
+
+	if len(stages) == 0 {
+		stages = []analysis.Stage{analysis.FilterStage(analysis.NewLowercaseFilter())}
+	}
+
+	return analysis.NewPipelineTokenizer(stages, analysis.NewWordTokenizer(alphabet.CreateAlphabet(description.Alphabet))), nil
+}
+
+// Suggest returns the documents, which words are similar to the query words, scored by the matching.
+// The documents out of the filter are skipped, if it is set. If the context is done before the search
+// is finished, the documents found by the matched so far query words are returned along with the context error
+func (w *wordIndex) Suggest(
+	ctx context.Context,
+	query string,
+	similarity float64,
+	metric metric.Metric,
+	matching WordMatching,
+	filter *roaring.Bitmap,
+) (map[dictionary.Key]float64, error) {
+	queryWords := w.splitter.Tokenize(query)
+	scores := make([]map[dictionary.Key]float64, 0, len(queryWords))
+	var err error
+
+	for i, word := range queryWords {
+		var matched map[dictionary.Key]float64
+		matched, err = w.match(ctx, word, i == len(queryWords)-1, similarity, metric, filter)
+		scores = append(scores, matched)
+
+		if err != nil {
+			break
+		}
+	}
+
+	return combineWordScores(scores, len(queryWords), matching), err
+}
+
+// match returns the documents, which contain a word similar to the given one, along with the best
+// word similarity. If prefix is true, the words starting with the given one are matched with the score 1
+func (w *wordIndex) match(
+	ctx context.Context,
+	word string,
+	prefix bool,
+	similarity float64,
+	metric metric.Metric,
+	filter *roaring.Bitmap,
+) (map[dictionary.Key]float64, error) {
+	candidates, err := w.words.Suggest(ctx, word, similarity, metric, newFuzzyCollectorManager(maxWordExpansions))
+
+	if err == nil && prefix {
+		var completions []Candidate
+		completions, err = w.words.Autocomplete(ctx, word, newFuzzyCollectorManager(maxWordExpansions))
+
+		for _, completion := range completions {
+			completion.Score = 1
+			candidates = append(candidates, completion)
+		}
+	}
+
+	matched := map[dictionary.Key]float64{}
+
+	for _, candidate := range candidates {
+		list := w.documents[candidate.Key]
+
+		if filter != nil {
+			list = roaring.And(list, filter)
+		}
+
+		it := list.Iterator()
+
+		for it.HasNext() {
+			key := it.Next()
+
+			if score, ok := matched[key]; !ok || score < candidate.Score {
+				matched[key] = candidate.Score
+			}
+		}
+	}
+
+	return matched, err
+}
+
+// combineWordScores combines the document scores of each matched query word with the given matching.
+// The number of query words can exceed the number of scores, if the search has been interrupted
+func combineWordScores(scores []map[dictionary.Key]float64, queryWords int, matching WordMatching) map[dictionary.Key]float64 {
+	combined := map[dictionary.Key]float64{}
+
+	if len(scores) == 0 {
+		return combined
+	}
+
+	if matching == AllWordsMatching {
+		for key := range scores[0] {
+			combined[key] = 0
+		}
+
+		for _, matched := range scores {
+			for key, score := range combined {
+				if wordScore, ok := matched[key]; ok {
+					combined[key] = score + wordScore
+				} else {
+					delete(combined, key)
+				}
+			}
+		}
+	} else {
+		for _, matched := range scores {
+			for key, score := range matched {
+				combined[key] += score
+			}
+		}
+	}
+
+	for key, score := range combined {
+		combined[key] = score / float64(queryWords)
+	}
+
+	return combined
+}
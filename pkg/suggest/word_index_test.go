@@ -0,0 +1,143 @@
+package suggest
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/metric"
+)
+
+func TestWordSplitter(t *testing.T) {
+	stemmed := IndexDescription{Alphabet: []string{"english"}}
+	assert.NoError(t, json.Unmarshal([]byte(`[{"type": "word"}, {"type": "stemmer", "lang": "en"}]`), &stemmed.Analysis))
+
+	testCases := []struct {
+		name        string
+		description IndexDescription
+		text        string
+		expected    []string
+	}{
+		{
+			name:        "lowercased",
+			description: IndexDescription{Alphabet: []string{"english", "numbers"}},
+			text:        "BMW X5, Mercedes-Benz",
+			expected:    []string{"bmw", "x5", "mercedes", "benz"},
+		},
+		{
+			name: "transliterated",
+			description: IndexDescription{
+				Alphabet:        []string{"english"},
+				Transliteration: "informal",
+			},
+			text:     "Мерседес Бенц",
+			expected: []string{"mersedes", "bents"},
+		},
+		{
+			name: "normalized",
+			description: IndexDescription{
+				Alphabet:      []string{"english"},
+				Normalization: &analysis.UnicodeNormalization{StripDiacritics: true},
+			},
+			text:     "Škoda Citroën",
+			expected: []string{"skoda", "citroen"},
+		},
+		{
+			name:        "stemmed",
+			description: stemmed,
+			text:        "Running Cars",
+			expected:    []string{"run", "car"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			splitter, err := newWordSplitter(testCase.description)
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, splitter.Tokenize(testCase.text))
+		})
+	}
+
+	_, err := newWordSplitter(IndexDescription{Transliteration: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestWordIndexAnalyzed(t *testing.T) {
+	description := IndexDescription{
+		NGramSize:       3,
+		Wrap:            [2]string{"$", "$"},
+		Pad:             "$",
+		Alphabet:        []string{"english", "numbers", "$"},
+		Transliteration: "informal",
+	}
+
+	dict := dictionary.NewInMemoryDictionary([]string{"Мерседес Бенц", "BMW X5", "Mercedes Sprinter"})
+	index, err := newWordIndex(dict, description)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		query    string
+		expected []dictionary.Key
+	}{
+		// the Cyrillic document words are matched by their Latin spelling and vice versa
+		{"mersedes", []dictionary.Key{0, 2}},
+		{"Спринтер", []dictionary.Key{2}},
+		// the last query word is completed in the analyzed form
+		{"Бенц Мерс", []dictionary.Key{0}},
+		// the uppercased query words are matched as well
+		{"X5 BMW", []dictionary.Key{1}},
+	}
+
+	for _, testCase := range testCases {
+		matched, err := index.Suggest(context.Background(), testCase.query, 0.5, metric.CosineMetric(), AllWordsMatching, nil)
+		assert.NoError(t, err)
+
+		keys := make([]dictionary.Key, 0, len(matched))
+
+		for key := range matched {
+			keys = append(keys, key)
+		}
+
+		assert.ElementsMatch(t, testCase.expected, keys, testCase.query)
+	}
+}
+
+func TestWordMatching(t *testing.T) {
+	service, description := newTestService(t, "BMW X5 xDrive\nBMW X6\nMercedes Benz GLE Coupe\nVolkswagen Golf\n", nil)
+
+	searchConf, err := NewSearchConfig("x5 bmw", 5, metric.CosineMetric(), 0.5, WithWordMatching(AllWordsMatching))
+	assert.NoError(t, err)
+
+	_, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.Error(t, err)
+
+	description.WordSearch = true
+	assert.NoError(t, service.AddRunTimeIndex(description))
+
+	result, err := service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"BMW X5 xDrive"}, resultValues(result))
+	assert.Equal(t, 1.0, result[0].Score)
+
+	// the last word is a prefix, the rest ones are fuzzy matched
+	searchConf, err = NewSearchConfig("coupe mercedez gl", 5, metric.CosineMetric(), 0.5, WithWordMatching(AllWordsMatching))
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Mercedes Benz GLE Coupe"}, resultValues(result))
+
+	searchConf, err = NewSearchConfig("bmw golf", 5, metric.CosineMetric(), 0.5, WithWordMatching(AnyWordMatching))
+	assert.NoError(t, err)
+
+	result, err = service.Suggest(context.Background(), description.Name, searchConf)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"BMW X5 xDrive", "BMW X6", "Volkswagen Golf"}, resultValues(result))
+	assert.Equal(t, 0.5, result[0].Score)
+
+	_, err = NewSearchConfig("bmw", 5, metric.CosineMetric(), 0.5, WithWordMatching("most"))
+	assert.Error(t, err)
+}
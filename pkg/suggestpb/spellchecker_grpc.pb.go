@@ -0,0 +1,165 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package suggestpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// SpellcheckerClient is the client API for Spellchecker service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SpellcheckerClient interface {
+	// Predict returns topK predictions for the query
+	Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*PredictResponse, error)
+	// PredictBatch performs Predict for each query of the batch and streams their predictions in the order of the queries
+	PredictBatch(ctx context.Context, in *PredictBatchRequest, opts ...grpc.CallOption) (Spellchecker_PredictBatchClient, error)
+}
+
+type spellcheckerClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpellcheckerClient(cc grpc.ClientConnInterface) SpellcheckerClient {
+	return &spellcheckerClient{cc}
+}
+
+func (c *spellcheckerClient) Predict(ctx context.Context, in *PredictRequest, opts ...grpc.CallOption) (*PredictResponse, error) {
+	out := new(PredictResponse)
+	err := c.cc.Invoke(ctx, "/suggest.Spellchecker/Predict", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *spellcheckerClient) PredictBatch(ctx context.Context, in *PredictBatchRequest, opts ...grpc.CallOption) (Spellchecker_PredictBatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Spellchecker_serviceDesc.Streams[0], "/suggest.Spellchecker/PredictBatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &spellcheckerPredictBatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Spellchecker_PredictBatchClient interface {
+	Recv() (*PredictBatchResponse, error)
+	grpc.ClientStream
+}
+
+type spellcheckerPredictBatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *spellcheckerPredictBatchClient) Recv() (*PredictBatchResponse, error) {
+	m := new(PredictBatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SpellcheckerServer is the server API for Spellchecker service.
+// All implementations must embed UnimplementedSpellcheckerServer
+// for forward compatibility
+type SpellcheckerServer interface {
+	// Predict returns topK predictions for the query
+	Predict(context.Context, *PredictRequest) (*PredictResponse, error)
+	// PredictBatch performs Predict for each query of the batch and streams their predictions in the order of the queries
+	PredictBatch(*PredictBatchRequest, Spellchecker_PredictBatchServer) error
+	mustEmbedUnimplementedSpellcheckerServer()
+}
+
+// UnimplementedSpellcheckerServer must be embedded to have forward compatible implementations.
+type UnimplementedSpellcheckerServer struct {
+}
+
+func (UnimplementedSpellcheckerServer) Predict(context.Context, *PredictRequest) (*PredictResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Predict not implemented")
+}
+func (UnimplementedSpellcheckerServer) PredictBatch(*PredictBatchRequest, Spellchecker_PredictBatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method PredictBatch not implemented")
+}
+func (UnimplementedSpellcheckerServer) mustEmbedUnimplementedSpellcheckerServer() {}
+
+// UnsafeSpellcheckerServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpellcheckerServer will
+// result in compilation errors.
+type UnsafeSpellcheckerServer interface {
+	mustEmbedUnimplementedSpellcheckerServer()
+}
+
+func RegisterSpellcheckerServer(s grpc.ServiceRegistrar, srv SpellcheckerServer) {
+	s.RegisterService(&_Spellchecker_serviceDesc, srv)
+}
+
+func _Spellchecker_Predict_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PredictRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpellcheckerServer).Predict(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/suggest.Spellchecker/Predict",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpellcheckerServer).Predict(ctx, req.(*PredictRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Spellchecker_PredictBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PredictBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SpellcheckerServer).PredictBatch(m, &spellcheckerPredictBatchServer{stream})
+}
+
+type Spellchecker_PredictBatchServer interface {
+	Send(*PredictBatchResponse) error
+	grpc.ServerStream
+}
+
+type spellcheckerPredictBatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *spellcheckerPredictBatchServer) Send(m *PredictBatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Spellchecker_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "suggest.Spellchecker",
+	HandlerType: (*SpellcheckerServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Predict",
+			Handler:    _Spellchecker_Predict_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PredictBatch",
+			Handler:       _Spellchecker_PredictBatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "spellchecker.proto",
+}
@@ -0,0 +1,241 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+
+package suggestpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion7
+
+// SuggestClient is the client API for Suggest service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SuggestClient interface {
+	// Suggest returns topK approximate strings for the query in the dictionary
+	Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// Autocomplete returns the candidates of the dictionary, that match the query
+	Autocomplete(ctx context.Context, in *AutocompleteRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// ListDictionaries returns the names of the managed dictionaries
+	ListDictionaries(ctx context.Context, in *ListDictionariesRequest, opts ...grpc.CallOption) (*ListDictionariesResponse, error)
+	// SuggestBatch performs Suggest for each query of the batch and streams their results in the order of the queries
+	SuggestBatch(ctx context.Context, in *SuggestBatchRequest, opts ...grpc.CallOption) (Suggest_SuggestBatchClient, error)
+}
+
+type suggestClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSuggestClient(cc grpc.ClientConnInterface) SuggestClient {
+	return &suggestClient{cc}
+}
+
+func (c *suggestClient) Suggest(ctx context.Context, in *SuggestRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/suggest.Suggest/Suggest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suggestClient) Autocomplete(ctx context.Context, in *AutocompleteRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, "/suggest.Suggest/Autocomplete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suggestClient) ListDictionaries(ctx context.Context, in *ListDictionariesRequest, opts ...grpc.CallOption) (*ListDictionariesResponse, error) {
+	out := new(ListDictionariesResponse)
+	err := c.cc.Invoke(ctx, "/suggest.Suggest/ListDictionaries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *suggestClient) SuggestBatch(ctx context.Context, in *SuggestBatchRequest, opts ...grpc.CallOption) (Suggest_SuggestBatchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Suggest_serviceDesc.Streams[0], "/suggest.Suggest/SuggestBatch", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &suggestSuggestBatchClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Suggest_SuggestBatchClient interface {
+	Recv() (*SuggestBatchResponse, error)
+	grpc.ClientStream
+}
+
+type suggestSuggestBatchClient struct {
+	grpc.ClientStream
+}
+
+func (x *suggestSuggestBatchClient) Recv() (*SuggestBatchResponse, error) {
+	m := new(SuggestBatchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SuggestServer is the server API for Suggest service.
+// All implementations must embed UnimplementedSuggestServer
+// for forward compatibility
+type SuggestServer interface {
+	// Suggest returns topK approximate strings for the query in the dictionary
+	Suggest(context.Context, *SuggestRequest) (*SearchResponse, error)
+	// Autocomplete returns the candidates of the dictionary, that match the query
+	Autocomplete(context.Context, *AutocompleteRequest) (*SearchResponse, error)
+	// ListDictionaries returns the names of the managed dictionaries
+	ListDictionaries(context.Context, *ListDictionariesRequest) (*ListDictionariesResponse, error)
+	// SuggestBatch performs Suggest for each query of the batch and streams their results in the order of the queries
+	SuggestBatch(*SuggestBatchRequest, Suggest_SuggestBatchServer) error
+	mustEmbedUnimplementedSuggestServer()
+}
+
+// UnimplementedSuggestServer must be embedded to have forward compatible implementations.
+type UnimplementedSuggestServer struct {
+}
+
+func (UnimplementedSuggestServer) Suggest(context.Context, *SuggestRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Suggest not implemented")
+}
+func (UnimplementedSuggestServer) Autocomplete(context.Context, *AutocompleteRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Autocomplete not implemented")
+}
+func (UnimplementedSuggestServer) ListDictionaries(context.Context, *ListDictionariesRequest) (*ListDictionariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDictionaries not implemented")
+}
+func (UnimplementedSuggestServer) SuggestBatch(*SuggestBatchRequest, Suggest_SuggestBatchServer) error {
+	return status.Errorf(codes.Unimplemented, "method SuggestBatch not implemented")
+}
+func (UnimplementedSuggestServer) mustEmbedUnimplementedSuggestServer() {}
+
+// UnsafeSuggestServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SuggestServer will
+// result in compilation errors.
+type UnsafeSuggestServer interface {
+	mustEmbedUnimplementedSuggestServer()
+}
+
+func RegisterSuggestServer(s grpc.ServiceRegistrar, srv SuggestServer) {
+	s.RegisterService(&_Suggest_serviceDesc, srv)
+}
+
+func _Suggest_Suggest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SuggestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuggestServer).Suggest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/suggest.Suggest/Suggest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuggestServer).Suggest(ctx, req.(*SuggestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Suggest_Autocomplete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AutocompleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuggestServer).Autocomplete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/suggest.Suggest/Autocomplete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuggestServer).Autocomplete(ctx, req.(*AutocompleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Suggest_ListDictionaries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDictionariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SuggestServer).ListDictionaries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/suggest.Suggest/ListDictionaries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SuggestServer).ListDictionaries(ctx, req.(*ListDictionariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Suggest_SuggestBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SuggestBatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SuggestServer).SuggestBatch(m, &suggestSuggestBatchServer{stream})
+}
+
+type Suggest_SuggestBatchServer interface {
+	Send(*SuggestBatchResponse) error
+	grpc.ServerStream
+}
+
+type suggestSuggestBatchServer struct {
+	grpc.ServerStream
+}
+
+func (x *suggestSuggestBatchServer) Send(m *SuggestBatchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Suggest_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "suggest.Suggest",
+	HandlerType: (*SuggestServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Suggest",
+			Handler:    _Suggest_Suggest_Handler,
+		},
+		{
+			MethodName: "Autocomplete",
+			Handler:    _Suggest_Autocomplete_Handler,
+		},
+		{
+			MethodName: "ListDictionaries",
+			Handler:    _Suggest_ListDictionaries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SuggestBatch",
+			Handler:       _Suggest_SuggestBatch_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "suggest.proto",
+}
@@ -1,26 +1,34 @@
-// +build ignore
-
 package vgram
 
 import "sort"
 
+// FrequencyTrie is a trie of the grams of a corpus, that counts the occurrences of each prefix
 type FrequencyTrie interface {
+	// Find returns the node of the given gram or nil if there is no such gram
 	Find(gram string) Node
+	// Add counts an occurrence of the given gram
 	Add(gram string)
+	// Walk calls the walker on each node of the trie except the root
 	Walk(walker func(key string, node Node))
+	// Prune removes the children of the nodes, so the frequency of each remaining gram doesn't exceed the threshold
 	Prune(threshold uint32)
 }
 
+// Node is a node of FrequencyTrie
 type Node interface {
 	frequencyHolder
+	// GetMarker returns the marker of the node, which tells that the node is a gram, or nil
 	GetMarker() Marker
 }
 
+// Marker is a leaf of a trie node, that counts the occurrences of the gram,
+// which can't be extended to a longer one
 type Marker interface {
 	frequencyHolder
 }
 
 type frequencyHolder interface {
+	// GetFrequency returns the number of occurrences
 	GetFrequency() uint32
 }
 
@@ -29,6 +37,7 @@ type trie struct {
 	qMin uint32
 }
 
+// NewFrequencyTrie creates a new FrequencyTrie, which nodes of at least qMin depth are grams
 func NewFrequencyTrie(qMin uint32) FrequencyTrie {
 	return &trie{
 		root: newNode(),
@@ -1,62 +1,247 @@
-// +build ignore
-
+// Package vgram provides the variable length grams (VGRAM) of Li, Wang and Yang, that split the strings
+// into the grams of high quality: the frequent grams are extended to the longer and more selective ones,
+// so the posting lists of an inverted index become shorter
 package vgram
 
 import (
-	"github.com/suggest-go/suggest/pkg/dictionary"
-	"github.com/suggest-go/suggest/pkg/index"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/suggest-go/suggest/pkg/analysis"
+	"github.com/suggest-go/suggest/pkg/store"
 )
 
+// VGramDictionaryBuilder builds the dictionary of the variable length grams of a corpus
 type VGramDictionaryBuilder struct {
 	qMin, qMax, threshold uint32
-	dictionary            dictionary.Dictionary
+	trie                  FrequencyTrie
 }
 
-type VGramDictionary struct{}
+// NewVGramDictionaryBuilder creates a new builder of the dictionary, which grams have from qMin to qMax characters.
+// A gram is extended to the longer grams, if it occurs in the corpus more than threshold times
+func NewVGramDictionaryBuilder(qMin, qMax, threshold uint32) (*VGramDictionaryBuilder, error) {
+	if qMin < 1 || qMin > qMax {
+		return nil, fmt.Errorf("gram lengths should satisfy 1 <= qMin <= qMax, got [%d, %d]", qMin, qMax)
+	}
 
-func (b *VGramDictionaryBuilder) Build() FrequencyTrie {
-	trie := b.buildFrequencyTrie()
-	b.pruneFrequencyTrie(trie, b.threshold)
-	return trie
+	return &VGramDictionaryBuilder{
+		qMin:      qMin,
+		qMax:      qMax,
+		threshold: threshold,
+		trie:      NewFrequencyTrie(qMin),
+	}, nil
 }
 
-func (b *VGramDictionaryBuilder) buildFrequencyTrie() FrequencyTrie {
-	freqTrie := NewFrequencyTrie(b.qMin)
+// Add counts the grams of the given string of the corpus
+func (b *VGramDictionaryBuilder) Add(text string) {
+	runes := []rune(text)
+
+	if len(runes) == 0 {
+		return
+	}
+
+	for _, gram := range splitIntoNGrams(runes, int(b.qMax)) {
+		b.trie.Add(gram)
+	}
+
+	// the shorter grams at the end of the string are counted as well, because they can't be extended
+	for q := int(b.qMax) - 1; q >= int(b.qMin); q-- {
+		p := len(runes) - q
+
+		if p < 0 {
+			continue
+		}
 
-	// omit error temporary
-	b.dictionary.Iterate(func(key dictionary.Key, value dictionary.Value) error {
-		if len(value) > 0 {
-			b.addWord(freqTrie, value)
+		for _, gram := range splitIntoNGrams(runes[p:], q) {
+			b.trie.Add(gram)
 		}
+	}
+}
 
-		return nil
+// Build prunes the frequency trie of the added strings and returns the dictionary of the remaining grams
+func (b *VGramDictionaryBuilder) Build() *VGramDictionary {
+	b.trie.Prune(b.threshold)
+
+	dict := &VGramDictionary{
+		qMin:  int(b.qMin),
+		qMax:  int(b.qMax),
+		grams: map[string]struct{}{},
+	}
+
+	b.trie.Walk(func(key string, node Node) {
+		if node.GetMarker() != nil {
+			dict.grams[key] = struct{}{}
+		}
 	})
 
-	return freqTrie
+	return dict
 }
 
-func (b *VGramDictionaryBuilder) addWord(freqTrie FrequencyTrie, word string) {
-	for _, gram := range index.SplitIntoNGrams(word, int(b.qMax)) {
-		freqTrie.Add(gram)
+// splitIntoNGrams returns all n-grams of the given string in the order of their positions
+func splitIntoNGrams(runes []rune, n int) []string {
+	if len(runes) < n {
+		return nil
+	}
+
+	grams := make([]string, 0, len(runes)-n+1)
+
+	for i := 0; i+n <= len(runes); i++ {
+		grams = append(grams, string(runes[i:i+n]))
 	}
 
-	runes := []rune(word)
-	lenC := len(runes)
+	return grams
+}
 
-	for q := b.qMax - 1; q >= b.qMin; q-- {
-		p := lenC - int(q)
-		if p < 0 {
+// VGramDictionary is a set of the variable length grams, that decomposes strings
+type VGramDictionary struct {
+	qMin, qMax int
+	grams      map[string]struct{}
+}
+
+// Size returns the number of the grams of the dictionary
+func (d *VGramDictionary) Size() int {
+	return len(d.grams)
+}
+
+// Has tells whether the dictionary contains the given gram
+func (d *VGramDictionary) Has(gram string) bool {
+	_, ok := d.grams[gram]
+
+	return ok
+}
+
+// Decompose returns the distinct grams of the given string. At each position the longest gram of
+// the dictionary is chosen, which is qMin-gram if there is no longer one, the grams, that are
+// contained in the previously chosen gram, are skipped
+func (d *VGramDictionary) Decompose(text string) []string {
+	runes := []rune(text)
+	grams := []string{}
+	seen := map[string]struct{}{}
+	end := 0
+
+	for p := 0; p+d.qMin <= len(runes); p++ {
+		length := d.qMin
+
+		for q := d.qMax; q > d.qMin; q-- {
+			if p+q <= len(runes) && d.Has(string(runes[p:p+q])) {
+				length = q
+				break
+			}
+		}
+
+		if p+length <= end {
 			continue
 		}
 
-		substr := string(runes[p:])
+		end = p + length
+		gram := string(runes[p:end])
 
-		for _, gram := range index.SplitIntoNGrams(substr, int(q)) {
-			freqTrie.Add(gram)
+		if _, ok := seen[gram]; !ok {
+			seen[gram] = struct{}{}
+			grams = append(grams, gram)
 		}
 	}
+
+	return grams
+}
+
+// Store encodes the dictionary into a binary form and saves the result into the provided Output.
+// Returns the number of written bytes or an error otherwise
+func (d *VGramDictionary) Store(out store.Output) (int, error) {
+	grams := make([]string, 0, len(d.grams))
+
+	for gram := range d.grams {
+		grams = append(grams, gram)
+	}
+
+	sort.Strings(grams)
+	total := 0
+
+	for _, v := range []int{d.qMin, d.qMax, len(grams)} {
+		n, err := out.WriteVUInt32(uint32(v))
+		total += n
+
+		if err != nil {
+			return total, fmt.Errorf("failed to write dictionary header: %w", err)
+		}
+	}
+
+	for _, gram := range grams {
+		n, err := out.WriteVUInt32(uint32(len(gram)))
+		total += n
+
+		if err != nil {
+			return total, fmt.Errorf("failed to write gram length: %w", err)
+		}
+
+		n, err = out.Write([]byte(gram))
+		total += n
+
+		if err != nil {
+			return total, fmt.Errorf("failed to write gram: %w", err)
+		}
+	}
+
+	return total, nil
+}
+
+// Load decodes the form generated by Store from the given Input.
+// Returns the number of read bytes or an error otherwise
+func (d *VGramDictionary) Load(in store.Input) (int, error) {
+	header := [3]uint32{}
+	start, err := in.Seek(0, io.SeekCurrent)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the position of dictionary: %w", err)
+	}
+
+	for i := range header {
+		if header[i], err = in.ReadVUInt32(); err != nil {
+			return 0, fmt.Errorf("failed to read dictionary header: %w", err)
+		}
+	}
+
+	d.qMin, d.qMax = int(header[0]), int(header[1])
+	d.grams = make(map[string]struct{}, header[2])
+
+	for i := uint32(0); i < header[2]; i++ {
+		length, err := in.ReadVUInt32()
+
+		if err != nil {
+			return 0, fmt.Errorf("failed to read gram length: %w", err)
+		}
+
+		buf := make([]byte, length)
+
+		if _, err := io.ReadFull(in, buf); err != nil {
+			return 0, fmt.Errorf("failed to read gram: %w", err)
+		}
+
+		d.grams[string(buf)] = struct{}{}
+	}
+
+	end, err := in.Seek(0, io.SeekCurrent)
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the position of dictionary: %w", err)
+	}
+
+	return int(end - start), nil
+}
+
+type tokenizer struct {
+	dict *VGramDictionary
+}
+
+// NewTokenizer returns a tokenizer, that decomposes a text into the grams of the given dictionary
+func NewTokenizer(dict *VGramDictionary) analysis.Tokenizer {
+	return &tokenizer{
+		dict: dict,
+	}
 }
 
-func (b *VGramDictionaryBuilder) pruneFrequencyTrie(freqTrie FrequencyTrie, threshold uint32) {
-	freqTrie.Prune(threshold)
+// Tokenize splits the given text on a sequence of tokens
+func (t *tokenizer) Tokenize(text string) []analysis.Token {
+	return t.dict.Decompose(text)
 }
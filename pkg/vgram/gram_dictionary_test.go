@@ -1,27 +1,21 @@
-// +build ignore
-
 package vgram
 
 import (
 	"reflect"
 	"testing"
 
-	"github.com/suggest-go/suggest/pkg/dictionary"
+	"github.com/suggest-go/suggest/pkg/store"
 )
 
 func TestBuildFrequencyTree(t *testing.T) {
-	dictionary := dictionary.NewInMemoryDictionary([]string{
-		"stick",
-		"stich",
-		"such",
-		"stuck",
-	})
+	builder, err := NewVGramDictionaryBuilder(2, 4, 2)
 
-	builder := &VGramDictionaryBuilder{
-		qMin:       2,
-		qMax:       4,
-		threshold:  2,
-		dictionary: dictionary,
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, word := range []string{"stick", "stich", "such", "stuck"} {
+		builder.Add(word)
 	}
 
 	type data struct {
@@ -62,9 +56,7 @@ func TestBuildFrequencyTree(t *testing.T) {
 	}
 
 	actual := make(map[string]data, 0)
-	trie := builder.buildFrequencyTrie()
-
-	trie.Walk(func(key string, node Node) {
+	builder.trie.Walk(func(key string, node Node) {
 		markerFreq := uint32(0)
 		marker := node.GetMarker()
 
@@ -87,3 +79,68 @@ func TestBuildFrequencyTree(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, actual)
 	}
 }
+
+func TestDecompose(t *testing.T) {
+	builder, err := NewVGramDictionaryBuilder(2, 4, 2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, word := range []string{"stick", "stich", "such", "stuck"} {
+		builder.Add(word)
+	}
+
+	dict := builder.Build()
+
+	testCases := []struct {
+		text     string
+		expected []string
+	}{
+		{"stick", []string{"sti", "ic", "ck"}},
+		{"stuck", []string{"st", "tu", "uc", "ck"}},
+		{"sticker", []string{"sti", "ic", "ck", "ke", "er"}},
+		{"s", []string{}},
+	}
+
+	for _, testCase := range testCases {
+		if actual := NewTokenizer(dict).Tokenize(testCase.text); !reflect.DeepEqual(testCase.expected, actual) {
+			t.Errorf("%s expected %v, got %v", testCase.text, testCase.expected, actual)
+		}
+	}
+
+	directory := store.NewRAMDirectory()
+	out, err := directory.CreateOutput("dict.vg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := dict.Store(out); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := out.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	in, err := directory.OpenInput("dict.vg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := &VGramDictionary{}
+
+	if _, err := loaded.Load(in); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(dict, loaded) {
+		t.Errorf("expected %v, got %v", dict, loaded)
+	}
+
+	if _, err := NewVGramDictionaryBuilder(3, 2, 1); err == nil {
+		t.Errorf("expected an error for qMin > qMax")
+	}
+}